@@ -11,23 +11,49 @@ func GetValue(key string, defaultKey string) string {
 }
 
 var (
-	RedisHost             = GetValue("redis.host", "")
-	RedisPort             = GetValue("redis.port", "")
-	RedisPass             = GetValue("redis.password", "")
-	JwtSecret             = GetValue("jwt.secret", "")
-	JwtTokenAccessExpire  = GetValue("jwt.access_expire", "")
-	JwtTokenRefreshExpire = GetValue("jwt.refresh_expire", "")
-	DbHost                = GetValue("database.host", "")
-	DbPort                = GetValue("database.port", "")
-	DbUser                = GetValue("database.user", "")
-	DbPassword            = GetValue("database.password", "")
-	DbName                = GetValue("database.name", "")
-	DbSSLMode             = GetValue("database.sslmode", "")
-	DbTimezone            = GetValue("database.timezone", "")
-	DbMaxConnections      = GetValue("database.max_connections", "")
-	DbIdleConnections     = GetValue("database.max_idle_connections", "")
-	AwsUrl                = GetValue("aws_base_url", "")
-	IsRunningCron         = GetValue("isRunningCron", "false")
-	WhatsAppUrl           = GetValue("whatsappUrl", "")
-	WhatsAppToken         = GetValue("whatsappToken", "")
+	RedisHost               = GetValue("redis.host", "")
+	RedisPort               = GetValue("redis.port", "")
+	RedisPass               = GetValue("redis.password", "")
+	JwtSecret               = GetValue("jwt.secret", "")
+	JwtTokenAccessExpire    = GetValue("jwt.access_expire", "")
+	JwtTokenRefreshExpire   = GetValue("jwt.refresh_expire", "")
+	JwtIssuer               = GetValue("jwt.issuer", "")
+	JwtAudience             = GetValue("jwt.audience", "")
+	DbHost                  = GetValue("database.host", "")
+	DbPort                  = GetValue("database.port", "")
+	DbUser                  = GetValue("database.user", "")
+	DbPassword              = GetValue("database.password", "")
+	DbName                  = GetValue("database.name", "")
+	DbSSLMode               = GetValue("database.sslmode", "")
+	DbTimezone              = GetValue("database.timezone", "")
+	DbMaxConnections        = GetValue("database.max_connections", "")
+	DbIdleConnections       = GetValue("database.max_idle_connections", "")
+	AwsUrl                  = GetValue("aws_base_url", "")
+	IsRunningCron           = GetValue("isRunningCron", "false")
+	WhatsAppUrl             = GetValue("whatsappUrl", "")
+	WhatsAppToken           = GetValue("whatsappToken", "")
+	MailDriver              = GetValue("mail.driver", "")
+	MailHost                = GetValue("mail.host", "")
+	MailPort                = GetValue("mail.port", "")
+	MailUsername            = GetValue("mail.username", "")
+	MailPassword            = GetValue("mail.password", "")
+	MailFrom                = GetValue("mail.from", "")
+	MailLogPath             = GetValue("mail.log_path", "")
+	PasswordResetUrl        = GetValue("password_reset.url", "")
+	PasswordResetExpire     = GetValue("password_reset.expire", "")
+	EmailVerificationUrl    = GetValue("email_verification.url", "")
+	EmailVerificationExpire = GetValue("email_verification.expire", "")
+	EmailVerificationGrace  = GetValue("email_verification.grace_period", "")
+	EmailChangeUrl          = GetValue("email_change.url", "")
+	SmsDriver               = GetValue("sms.driver", "")
+	SmsLogPath              = GetValue("sms.log_path", "")
+	OtpSecret               = GetValue("otp.secret", "")
+	LoginUnlockUrl          = GetValue("login_unlock.url", "")
+	AccountDeletionGrace    = GetValue("account_deletion.grace_period", "")
+	DataExportPath          = GetValue("data_export.path", "")
+	DataExportExpire        = GetValue("data_export.expire", "")
+	ArgonMemory             = GetValue("password.argon_memory", "")
+	ArgonIterations         = GetValue("password.argon_iterations", "")
+	ArgonParallelism        = GetValue("password.argon_parallelism", "")
+	PasswordActivePepper    = GetValue("password.active_pepper", "")
 )
@@ -0,0 +1,31 @@
+package config
+
+import "fmt"
+
+// JwtKeyConfig describes one asymmetric signing key, loaded from PEM files
+type JwtKeyConfig struct {
+	Kid string `mapstructure:"kid"`
+	// RS256 or EdDSA
+	Algorithm string `mapstructure:"algorithm"`
+	// Path to the private key, can be empty for retired keys that only verify old tokens
+	PrivateKey string `mapstructure:"private_key"`
+	// Path to the public key, derived from the private key when empty
+	PublicKey string `mapstructure:"public_key"`
+}
+
+// JwtKeysConfig holds the keys used to sign and verify tokens
+type JwtKeysConfig struct {
+	ActiveKid string         `mapstructure:"active_kid"`
+	Keys      []JwtKeyConfig `mapstructure:"keys"`
+}
+
+func GetJwtKeysConfig() (JwtKeysConfig, error) {
+	v := NewViperConfig()
+	var jwtKeysConfig JwtKeysConfig
+
+	if err := v.UnmarshalKey("jwt", &jwtKeysConfig); err != nil {
+		return jwtKeysConfig, fmt.Errorf("failed to unmarshal jwt config: %w", err)
+	}
+
+	return jwtKeysConfig, nil
+}
@@ -0,0 +1,27 @@
+package config
+
+// OidcProviderConfig describes one OpenID Connect provider used for social login
+type OidcProviderConfig struct {
+	Issuer       string `mapstructure:"issuer"`
+	ClientId     string `mapstructure:"client_id"`
+	ClientSecret string `mapstructure:"client_secret"`
+	RedirectUrl  string `mapstructure:"redirect_url"`
+	// Endpoints below are discovered from the issuer when empty
+	AuthorizationUrl string   `mapstructure:"authorization_url"`
+	TokenUrl         string   `mapstructure:"token_url"`
+	JwksUrl          string   `mapstructure:"jwks_url"`
+	Scopes           []string `mapstructure:"scopes"`
+	// Apple requires form_post when the name or email scope is requested
+	ResponseMode string `mapstructure:"response_mode"`
+}
+
+func GetOidcProviders() map[string]OidcProviderConfig {
+	v := NewViperConfig()
+	var providers map[string]OidcProviderConfig
+
+	if err := v.UnmarshalKey("oidc.providers", &providers); err != nil {
+		panic("failed to unmarshal oidc config: " + err.Error())
+	}
+
+	return providers
+}
@@ -0,0 +1,16 @@
+package config
+
+import "fmt"
+
+// GetPasswordPeppers returns the configured peppers keyed by id. Viper lowercases map keys,
+// so the ids are lowercase.
+func GetPasswordPeppers() (map[string]string, error) {
+	v := NewViperConfig()
+	var peppers map[string]string
+
+	if err := v.UnmarshalKey("password.peppers", &peppers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal password peppers: %w", err)
+	}
+
+	return peppers, nil
+}
@@ -0,0 +1,24 @@
+package config
+
+// PasswordPolicyConfig describes the rules a new password has to follow
+type PasswordPolicyConfig struct {
+	MinLength        int      `mapstructure:"min_length"`
+	RequireLowercase bool     `mapstructure:"require_lowercase"`
+	RequireUppercase bool     `mapstructure:"require_uppercase"`
+	RequireDigit     bool     `mapstructure:"require_digit"`
+	RequireSymbol    bool     `mapstructure:"require_symbol"`
+	BannedWords      []string `mapstructure:"banned_words"`
+	// File or directory with SHA-1 hashes of breached passwords, empty disables the check
+	BreachedListPath string `mapstructure:"breached_list_path"`
+}
+
+func GetPasswordPolicy() PasswordPolicyConfig {
+	v := NewViperConfig()
+	var policy PasswordPolicyConfig
+
+	if err := v.UnmarshalKey("password.policy", &policy); err != nil {
+		panic("failed to unmarshal password policy config: " + err.Error())
+	}
+
+	return policy
+}
@@ -1,13 +1,17 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"time"
 
+	"alfredo/tabunganku/config"
 	"alfredo/tabunganku/pkg/injectors"
+	"alfredo/tabunganku/pkg/repositories"
 	"alfredo/tabunganku/pkg/router"
+	"alfredo/tabunganku/pkg/services"
 
 	_ "alfredo/tabunganku/docs" // Import docs untuk swagger
 )
@@ -36,6 +40,21 @@ func main() {
 	time.Local = time.UTC
 
 	server := injectors.InitializeApplication()
+
+	// Secret dan kunci JWT dicek saat boot, bukan saat request pertama
+	if err := services.ValidateOtpConfig(); err != nil {
+		server.Logger.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	if err := services.LoadJwtKeys(); err != nil {
+		server.Logger.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	if err := services.CheckPasswordPeppers(repositories.NewUserRepository(server.Db)); err != nil {
+		server.Logger.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+
 	server.RegisterMiddlewares()
 	router.InitializeRouterV1(server)
 
@@ -46,12 +65,20 @@ func main() {
 		}
 	}()
 
+	// Purge akun yang dihapus dan export yang kedaluwarsa berjalan di background
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	if config.IsRunningCron == "true" {
+		go injectors.InitializeAccountJobs().Run(jobsCtx)
+	}
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
 	<-c
 
 	server.Logger.Info("Shutting down server")
+	stopJobs()
 	if err := server.App.ShutdownWithTimeout(10 * time.Second); err != nil {
 		server.Logger.Error("Failed to shutdown server", "error", err)
 	}
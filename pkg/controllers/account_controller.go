@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/middleware/jwt"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/services"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+type AccountController interface {
+	Router(router fiber.Router)
+	RequestExport(c *fiber.Ctx) error
+	GetExport(c *fiber.Ctx) error
+	DownloadExport(c *fiber.Ctx) error
+	DeleteAccount(c *fiber.Ctx) error
+	CancelDeletion(c *fiber.Ctx) error
+}
+
+type accountController struct {
+	dataExportService      services.DataExportService
+	accountDeletionService services.AccountDeletionService
+	userService            services.UserService
+	redisService           services.RedisService
+}
+
+// RequestExport godoc
+// @Summary Export personal data
+// @Description Start building a zip with the profile, savings, transactions and uploaded images. Poll the export until it is completed, an email is sent when it is ready.
+// @Tags account
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 202 {object} dtos.SuccessResponse{data=dtos.DataExportResponse}
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me/export [post]
+func (ac *accountController) RequestExport(c *fiber.Ctx) error {
+	export, err := ac.dataExportService.RequestExport(c.Locals("user").(*models.User))
+	if err != nil {
+		return accountErrorResponse(c, "Failed to start data export", err)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Data export started",
+		Data:    export,
+	})
+}
+
+// GetExport godoc
+// @Summary Get data export status
+// @Description Get the status of a data export
+// @Tags account
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Export UUID"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.DataExportResponse}
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me/export/{uuid} [get]
+func (ac *accountController) GetExport(c *fiber.Ctx) error {
+	export, err := ac.dataExportService.GetExport(c.Locals("user_uuid").(string), c.Params("uuid"))
+	if err != nil {
+		return accountErrorResponse(c, "Failed to get data export", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Data export retrieved successfully",
+		Data:    export,
+	})
+}
+
+// DownloadExport godoc
+// @Summary Download data export
+// @Description Download the zip of a completed data export
+// @Tags account
+// @Produce application/zip
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Export UUID"
+// @Success 200 {file} file
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me/export/{uuid}/download [get]
+func (ac *accountController) DownloadExport(c *fiber.Ctx) error {
+	path, err := ac.dataExportService.ExportFile(c.Locals("user_uuid").(string), c.Params("uuid"))
+	if err != nil {
+		return accountErrorResponse(c, "Failed to download data export", err)
+	}
+
+	return c.Download(path, "tabunganku-export.zip")
+}
+
+// DeleteAccount godoc
+// @Summary Delete account
+// @Description Schedule the account for deletion. Every session is logged out, the data is purged after the grace period unless the deletion is cancelled.
+// @Tags account
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dtos.DeleteAccountRequest true "Current password"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.AccountDeletionResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 429 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me [delete]
+func (ac *accountController) DeleteAccount(c *fiber.Ctx) error {
+	var request dtos.DeleteAccountRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	response, err := ac.accountDeletionService.ScheduleDeletion(c.Locals("user").(*models.User), &request)
+	if err != nil {
+		return accountErrorResponse(c, "Failed to delete account", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Account scheduled for deletion",
+		Data:    response,
+	})
+}
+
+// CancelDeletion godoc
+// @Summary Cancel account deletion
+// @Description Keep the account when a deletion was requested and the grace period has not passed yet
+// @Tags account
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me/deletion/cancel [post]
+func (ac *accountController) CancelDeletion(c *fiber.Ctx) error {
+	if err := ac.accountDeletionService.CancelDeletion(c.Locals("user").(*models.User)); err != nil {
+		return accountErrorResponse(c, "Failed to cancel account deletion", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Account deletion cancelled",
+	})
+}
+
+// accountErrorResponse maps export and deletion errors to the matching HTTP status
+func accountErrorResponse(c *fiber.Ctx, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	var validationErr *validator.ValidationError
+	switch {
+	case errors.As(err, &validationErr),
+		errors.Is(err, services.ErrCurrentPasswordInvalid),
+		errors.Is(err, services.ErrAccountDeletionNotScheduled):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, repositories.ErrDataExportNotFound), errors.Is(err, repositories.ErrUserNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, repositories.ErrDataExportInProgress),
+		errors.Is(err, services.ErrDataExportNotReady),
+		errors.Is(err, services.ErrAccountDeletionScheduled):
+		status = fiber.StatusConflict
+	case errors.Is(err, services.ErrPasswordCheckLimited):
+		status = fiber.StatusTooManyRequests
+	}
+
+	return c.Status(status).JSON(dtos.ErrorResponseDTO{
+		Success: false,
+		Message: message,
+		Code:    status,
+		Errors:  err.Error(),
+	})
+}
+
+// Router implements AccountController.
+// Mounted on /me itself like the profile routes, so the middleware is added per route.
+func (ac *accountController) Router(router fiber.Router) {
+	authenticated := jwt.JwtMiddleware(ac.userService, ac.redisService)
+	withScope := jwt.RequireScope(services.ScopeAccount)
+
+	router.Post("/export", authenticated, withScope, ac.RequestExport)
+	router.Get("/export/:uuid", authenticated, withScope, ac.GetExport)
+	router.Get("/export/:uuid/download", authenticated, withScope, ac.DownloadExport)
+	router.Delete("/", authenticated, withScope, ac.DeleteAccount)
+	router.Post("/deletion/cancel", authenticated, withScope, ac.CancelDeletion)
+}
+
+func NewAccountController(
+	dataExportService services.DataExportService,
+	accountDeletionService services.AccountDeletionService,
+	userService services.UserService,
+	redisService services.RedisService,
+) AccountController {
+	return &accountController{
+		dataExportService:      dataExportService,
+		accountDeletionService: accountDeletionService,
+		userService:            userService,
+		redisService:           redisService,
+	}
+}
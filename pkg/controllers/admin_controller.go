@@ -0,0 +1,429 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/middleware/jwt"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/services"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+type AdminController interface {
+	Router(router fiber.Router)
+	SearchUsers(c *fiber.Ctx) error
+	GetUser(c *fiber.Ctx) error
+	DisableUser(c *fiber.Ctx) error
+	EnableUser(c *fiber.Ctx) error
+	ResetPassword(c *fiber.Ctx) error
+	GetRoles(c *fiber.Ctx) error
+	AssignRole(c *fiber.Ctx) error
+	RemoveRole(c *fiber.Ctx) error
+	Impersonate(c *fiber.Ctx) error
+	EndImpersonation(c *fiber.Ctx) error
+	GetImpersonations(c *fiber.Ctx) error
+}
+
+type adminController struct {
+	adminService services.AdminService
+	rbacService  services.RbacService
+	redisService services.RedisService
+	userService  services.UserService
+}
+
+// SearchUsers godoc
+// @Summary Search users
+// @Description Search user accounts by name, email or phone number
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param q query string false "Search query"
+// @Param page query int false "Page, starts from 1"
+// @Param limit query int false "Items per page, max 100"
+// @Success 200 {object} dtos.PaginatedSuccessResponse{data=[]dtos.AdminUserResponse}
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/users [get]
+func (ac *adminController) SearchUsers(c *fiber.Ctx) error {
+	var filter dtos.AdminUserFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid query parameters",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	users, meta, err := ac.adminService.SearchUsers(&filter)
+	if err != nil {
+		return adminErrorResponse(c, "Failed to search users", err)
+	}
+
+	return c.JSON(dtos.PaginatedSuccessResponse{
+		Success: true,
+		Message: "Users retrieved successfully",
+		Data:    users,
+		Meta:    meta,
+	})
+}
+
+// GetUser godoc
+// @Summary Get a user
+// @Description Get a user account with its roles
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "User UUID"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.AdminUserResponse}
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/users/{uuid} [get]
+func (ac *adminController) GetUser(c *fiber.Ctx) error {
+	user, err := ac.adminService.GetUser(c.Params("uuid"))
+	if err != nil {
+		return adminErrorResponse(c, "Failed to get user", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "User retrieved successfully",
+		Data:    user,
+	})
+}
+
+// DisableUser godoc
+// @Summary Disable a user
+// @Description Disable a user account. The user is logged out everywhere and can not login until enabled again.
+// @Description Accounts with a role can only be disabled by admins with the roles:manage permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "User UUID"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/users/{uuid}/disable [post]
+func (ac *adminController) DisableUser(c *fiber.Ctx) error {
+	if err := ac.adminService.DisableUser(c.Locals("user_uuid").(string), c.Params("uuid")); err != nil {
+		return adminErrorResponse(c, "Failed to disable user", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "User disabled successfully",
+	})
+}
+
+// EnableUser godoc
+// @Summary Enable a user
+// @Description Enable a disabled user account
+// @Description Accounts with a role can only be enabled by admins with the roles:manage permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "User UUID"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/users/{uuid}/enable [post]
+func (ac *adminController) EnableUser(c *fiber.Ctx) error {
+	if err := ac.adminService.EnableUser(c.Locals("user_uuid").(string), c.Params("uuid")); err != nil {
+		return adminErrorResponse(c, "Failed to enable user", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "User enabled successfully",
+	})
+}
+
+// ResetPassword godoc
+// @Summary Reset a user password
+// @Description Invalidate the current password, log the user out everywhere and email a password reset link
+// @Description Accounts with a role can only be reset by admins with the roles:manage permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "User UUID"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/users/{uuid}/reset-password [post]
+func (ac *adminController) ResetPassword(c *fiber.Ctx) error {
+	if err := ac.adminService.ResetPassword(c.Locals("user_uuid").(string), c.Params("uuid")); err != nil {
+		return adminErrorResponse(c, "Failed to reset password", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Password reset link sent",
+	})
+}
+
+// GetRoles godoc
+// @Summary Get roles
+// @Description Get every role with its permissions
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dtos.SuccessResponse{data=[]dtos.RoleResponse}
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/roles [get]
+func (ac *adminController) GetRoles(c *fiber.Ctx) error {
+	roles, err := ac.rbacService.GetRoles()
+	if err != nil {
+		return adminErrorResponse(c, "Failed to get roles", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Roles retrieved successfully",
+		Data:    roles,
+	})
+}
+
+// AssignRole godoc
+// @Summary Assign a role
+// @Description Give a role to a user
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "User UUID"
+// @Param request body dtos.AssignRoleRequest true "Role name"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/users/{uuid}/roles [post]
+func (ac *adminController) AssignRole(c *fiber.Ctx) error {
+	var request dtos.AssignRoleRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	if err := ac.rbacService.AssignRole(c.Params("uuid"), &request); err != nil {
+		return adminErrorResponse(c, "Failed to assign role", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Role assigned successfully",
+	})
+}
+
+// RemoveRole godoc
+// @Summary Remove a role
+// @Description Take a role away from a user
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "User UUID"
+// @Param role path string true "Role name"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/users/{uuid}/roles/{role} [delete]
+func (ac *adminController) RemoveRole(c *fiber.Ctx) error {
+	if err := ac.rbacService.RemoveRole(c.Params("uuid"), c.Params("role")); err != nil {
+		return adminErrorResponse(c, "Failed to remove role", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Role removed successfully",
+	})
+}
+
+// Impersonate godoc
+// @Summary Impersonate a user
+// @Description Get a read-only token to see the app as the user. Write requests made with the token are rejected and the impersonation is written to the audit log.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "User UUID"
+// @Param request body dtos.ImpersonateRequest true "Reason for the impersonation"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.ImpersonationResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/users/{uuid}/impersonate [post]
+func (ac *adminController) Impersonate(c *fiber.Ctx) error {
+	var request dtos.ImpersonateRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	request.IPAddress = c.IP()
+	request.UserAgent = c.Get(fiber.HeaderUserAgent)
+
+	response, err := ac.adminService.Impersonate(c.Locals("user_uuid").(string), c.Params("uuid"), &request)
+	if err != nil {
+		return adminErrorResponse(c, "Failed to impersonate user", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Impersonation started",
+		Data:    response,
+	})
+}
+
+// EndImpersonation godoc
+// @Summary End an impersonation
+// @Description Invalidate the impersonation token before it expires
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Impersonation UUID"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/impersonations/{uuid}/end [post]
+func (ac *adminController) EndImpersonation(c *fiber.Ctx) error {
+	if err := ac.adminService.EndImpersonation(c.Params("uuid")); err != nil {
+		return adminErrorResponse(c, "Failed to end impersonation", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Impersonation ended",
+	})
+}
+
+// GetImpersonations godoc
+// @Summary Get the impersonation audit log
+// @Description List impersonations, newest first, optionally filtered by admin or user
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param admin_uuid query string false "Admin UUID"
+// @Param user_uuid query string false "User UUID"
+// @Param page query int false "Page, starts from 1"
+// @Param limit query int false "Items per page, max 100"
+// @Success 200 {object} dtos.PaginatedSuccessResponse{data=[]dtos.ImpersonationAuditResponse}
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/impersonations [get]
+func (ac *adminController) GetImpersonations(c *fiber.Ctx) error {
+	var filter dtos.ImpersonationFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid query parameters",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	audits, meta, err := ac.adminService.GetImpersonations(&filter)
+	if err != nil {
+		return adminErrorResponse(c, "Failed to get impersonations", err)
+	}
+
+	return c.JSON(dtos.PaginatedSuccessResponse{
+		Success: true,
+		Message: "Impersonations retrieved successfully",
+		Data:    audits,
+		Meta:    meta,
+	})
+}
+
+// adminErrorResponse maps admin errors to the matching HTTP status
+func adminErrorResponse(c *fiber.Ctx, message string, err error) error {
+	var validationErr *validator.ValidationError
+
+	code := fiber.StatusInternalServerError
+	switch {
+	case errors.As(err, &validationErr),
+		errors.Is(err, services.ErrCannotDisableSelf),
+		errors.Is(err, services.ErrCannotImpersonateSelf),
+		errors.Is(err, services.ErrAccountDisabled):
+		code = fiber.StatusBadRequest
+	case errors.Is(err, services.ErrCannotImpersonateStaff), errors.Is(err, services.ErrCannotManageStaff):
+		code = fiber.StatusForbidden
+	case errors.Is(err, repositories.ErrUserNotFound),
+		errors.Is(err, repositories.ErrRoleNotFound),
+		errors.Is(err, repositories.ErrImpersonationNotFound):
+		code = fiber.StatusNotFound
+	}
+
+	return c.Status(code).JSON(dtos.ErrorResponseDTO{
+		Success: false,
+		Message: message,
+		Code:    code,
+		Errors:  err.Error(),
+	})
+}
+
+// Router implements AdminController.
+func (ac *adminController) Router(router fiber.Router) {
+	// Admin API hanya untuk login biasa, API key dan webview token tidak punya scope account.
+	// Middleware dipasang per group supaya tidak ikut berjalan di group admin lain seperti /currencies.
+	authenticated := []interface{}{jwt.JwtMiddleware(ac.userService, ac.redisService), jwt.RequireScope(services.ScopeAccount)}
+	canRead := jwt.RequirePermission(ac.rbacService, services.PermissionUsersRead)
+	canWrite := jwt.RequirePermission(ac.rbacService, services.PermissionUsersWrite)
+	canManageRoles := jwt.RequirePermission(ac.rbacService, services.PermissionRolesManage)
+	canImpersonate := jwt.RequirePermission(ac.rbacService, services.PermissionUsersImpersonate)
+
+	users := router.Group("/users").Use(authenticated...)
+	{
+		users.Get("/", canRead, ac.SearchUsers)
+		users.Get("/:uuid", canRead, ac.GetUser)
+		users.Post("/:uuid/disable", canWrite, ac.DisableUser)
+		users.Post("/:uuid/enable", canWrite, ac.EnableUser)
+		users.Post("/:uuid/reset-password", canWrite, ac.ResetPassword)
+		users.Post("/:uuid/roles", canManageRoles, ac.AssignRole)
+		users.Delete("/:uuid/roles/:role", canManageRoles, ac.RemoveRole)
+		users.Post("/:uuid/impersonate", canImpersonate, ac.Impersonate)
+	}
+
+	impersonations := router.Group("/impersonations").Use(authenticated...)
+	{
+		impersonations.Get("/", canRead, ac.GetImpersonations)
+		impersonations.Post("/:uuid/end", canImpersonate, ac.EndImpersonation)
+	}
+
+	roles := router.Group("/roles").Use(authenticated...)
+	{
+		roles.Get("/", canManageRoles, ac.GetRoles)
+	}
+}
+
+func NewAdminController(adminService services.AdminService, rbacService services.RbacService, redisService services.RedisService, userService services.UserService) AdminController {
+	return &adminController{adminService: adminService, rbacService: rbacService, redisService: redisService, userService: userService}
+}
@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/middleware/jwt"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/services"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+type ApiKeyController interface {
+	Router(router fiber.Router)
+	CreateApiKey(c *fiber.Ctx) error
+	GetApiKeys(c *fiber.Ctx) error
+	RevokeApiKey(c *fiber.Ctx) error
+}
+
+type apiKeyController struct {
+	apiKeyService services.ApiKeyService
+	redisService  services.RedisService
+	userService   services.UserService
+}
+
+// CreateApiKey godoc
+// @Summary Create an API key
+// @Description Create a personal API key for scripts. Send it as "Authorization: ApiKey <key>". The key is only shown in this response.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dtos.CreateApiKeyRequest true "API key data, scopes can be savings:read and savings:write"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.CreateApiKeyResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me/api-keys [post]
+func (ac *apiKeyController) CreateApiKey(c *fiber.Ctx) error {
+	var request dtos.CreateApiKeyRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	request.UserUUID = c.Locals("user_uuid").(string)
+
+	apiKey, err := ac.apiKeyService.CreateApiKey(&request)
+	if err != nil {
+		return apiKeyErrorResponse(c, "Failed to create api key", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Api key created successfully",
+		Data:    apiKey,
+	})
+}
+
+// GetApiKeys godoc
+// @Summary Get API keys
+// @Description Get the active API keys of the authenticated user, without the secret part
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dtos.SuccessResponse{data=[]dtos.ApiKeyResponse}
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me/api-keys [get]
+func (ac *apiKeyController) GetApiKeys(c *fiber.Ctx) error {
+	apiKeys, err := ac.apiKeyService.GetApiKeys(c.Locals("user_uuid").(string))
+	if err != nil {
+		return apiKeyErrorResponse(c, "Failed to get api keys", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Api keys retrieved successfully",
+		Data:    apiKeys,
+	})
+}
+
+// RevokeApiKey godoc
+// @Summary Revoke an API key
+// @Description Revoke an API key, requests using it are rejected immediately
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "API key UUID"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me/api-keys/{uuid} [delete]
+func (ac *apiKeyController) RevokeApiKey(c *fiber.Ctx) error {
+	if err := ac.apiKeyService.RevokeApiKey(c.Locals("user_uuid").(string), c.Params("uuid")); err != nil {
+		return apiKeyErrorResponse(c, "Failed to revoke api key", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Api key revoked successfully",
+	})
+}
+
+// apiKeyErrorResponse maps api key errors to the matching HTTP status
+func apiKeyErrorResponse(c *fiber.Ctx, message string, err error) error {
+	var validationErr *validator.ValidationError
+
+	code := fiber.StatusInternalServerError
+	switch {
+	case errors.As(err, &validationErr),
+		errors.Is(err, services.ErrApiKeyScopeNotAllowed),
+		errors.Is(err, services.ErrApiKeyExpiryInPast):
+		code = fiber.StatusBadRequest
+	case errors.Is(err, repositories.ErrApiKeyNotFound):
+		code = fiber.StatusNotFound
+	}
+
+	return c.Status(code).JSON(dtos.ErrorResponseDTO{
+		Success: false,
+		Message: message,
+		Code:    code,
+		Errors:  err.Error(),
+	})
+}
+
+// Router implements ApiKeyController.
+func (ac *apiKeyController) Router(router fiber.Router) {
+	// Key hanya bisa dikelola dari login biasa, API key tidak punya scope account
+	withMiddleware := router.Use(jwt.JwtMiddleware(ac.userService, ac.redisService), jwt.RequireScope(services.ScopeAccount))
+	{
+		withMiddleware.Post("/", ac.CreateApiKey)
+		withMiddleware.Get("/", ac.GetApiKeys)
+		withMiddleware.Delete("/:uuid", ac.RevokeApiKey)
+	}
+}
+
+func NewApiKeyController(apiKeyService services.ApiKeyService, redisService services.RedisService, userService services.UserService) ApiKeyController {
+	return &apiKeyController{apiKeyService: apiKeyService, redisService: redisService, userService: userService}
+}
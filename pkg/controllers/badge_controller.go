@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/middleware/jwt"
+	"alfredo/tabunganku/pkg/services"
+)
+
+type BadgeController interface {
+	Router(router fiber.Router)
+	GetBadges(c *fiber.Ctx) error
+}
+
+type badgeController struct {
+	badgeService services.BadgeService
+	redisService services.RedisService
+	userService  services.UserService
+}
+
+// GetBadges godoc
+// @Summary Get user badges
+// @Description Get every achievement badge with whether the authenticated user has earned it
+// @Tags badges
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dtos.SuccessResponse{data=[]dtos.UserBadgeResponse}
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me/badges [get]
+func (bc *badgeController) GetBadges(c *fiber.Ctx) error {
+	badges, err := bc.badgeService.GetUserBadges(c.Locals("user_uuid").(string))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Failed to get badges",
+			Code:    fiber.StatusInternalServerError,
+			Errors:  err.Error(),
+		})
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Badges retrieved successfully",
+		Data:    badges,
+	})
+}
+
+// Router implements BadgeController.
+func (bc *badgeController) Router(router fiber.Router) {
+	withMiddleware := router.Use(jwt.JwtMiddleware(bc.userService, bc.redisService))
+	{
+		withMiddleware.Get("/", jwt.RequireScope(services.ScopeSavingsRead), bc.GetBadges)
+	}
+}
+
+func NewBadgeController(badgeService services.BadgeService, redisService services.RedisService, userService services.UserService) BadgeController {
+	return &badgeController{badgeService: badgeService, redisService: redisService, userService: userService}
+}
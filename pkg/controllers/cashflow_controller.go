@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/middleware/jwt"
+	"alfredo/tabunganku/pkg/services"
+)
+
+type CashflowController interface {
+	Router(router fiber.Router)
+	PostCashflow(c *fiber.Ctx) error
+}
+
+type cashflowController struct {
+	ruleService  services.RuleService
+	redisService services.RedisService
+	userService  services.UserService
+}
+
+// PostCashflow godoc
+// @Summary Post a cashflow event
+// @Description Post an income or expense. Active saving rules are evaluated against it and the generated deposits are returned.
+// @Description Events with an external_id that was already posted are rejected.
+// @Tags cashflows
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dtos.CashflowRequest true "Cashflow data"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.CashflowResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /cashflows [post]
+func (cc *cashflowController) PostCashflow(c *fiber.Ctx) error {
+	var request dtos.CashflowRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	request.UserUUID = c.Locals("user_uuid").(string)
+
+	cashflow, err := cc.ruleService.PostCashflow(&request)
+	if err != nil {
+		return ruleErrorResponse(c, "Failed to post cashflow", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Cashflow posted successfully",
+		Data:    cashflow,
+	})
+}
+
+// Router implements CashflowController.
+func (cc *cashflowController) Router(router fiber.Router) {
+	withMiddleware := router.Use(jwt.JwtMiddleware(cc.userService, cc.redisService))
+	{
+		withMiddleware.Post("/", jwt.RequireScope(services.ScopeSavingsWrite), cc.PostCashflow)
+	}
+}
+
+func NewCashflowController(ruleService services.RuleService, redisService services.RedisService, userService services.UserService) CashflowController {
+	return &cashflowController{ruleService: ruleService, redisService: redisService, userService: userService}
+}
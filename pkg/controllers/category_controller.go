@@ -0,0 +1,215 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/middleware/jwt"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/services"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+type CategoryController interface {
+	Router(router fiber.Router)
+	CreateCategory(c *fiber.Ctx) error
+	GetCategories(c *fiber.Ctx) error
+	GetCategory(c *fiber.Ctx) error
+	UpdateCategory(c *fiber.Ctx) error
+	DeleteCategory(c *fiber.Ctx) error
+}
+
+type categoryController struct {
+	categoryService services.CategoryService
+	redisService    services.RedisService
+	userService     services.UserService
+}
+
+// CreateCategory godoc
+// @Summary Create a new category
+// @Description Create a user-defined category to group savings
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dtos.CategoryRequest true "Category data"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.CategoryResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /categories [post]
+func (cc *categoryController) CreateCategory(c *fiber.Ctx) error {
+	var request dtos.CategoryRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	request.UserUUID = c.Locals("user_uuid").(string)
+
+	category, err := cc.categoryService.CreateCategory(&request)
+	if err != nil {
+		return categoryErrorResponse(c, "Failed to create category", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Category created successfully",
+		Data:    category,
+	})
+}
+
+// GetCategories godoc
+// @Summary Get user categories
+// @Description Get all categories of the authenticated user
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dtos.SuccessResponse{data=[]dtos.CategoryResponse}
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /categories [get]
+func (cc *categoryController) GetCategories(c *fiber.Ctx) error {
+	categories, err := cc.categoryService.GetCategories(c.Locals("user_uuid").(string))
+	if err != nil {
+		return categoryErrorResponse(c, "Failed to get categories", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Categories retrieved successfully",
+		Data:    categories,
+	})
+}
+
+// GetCategory godoc
+// @Summary Get a category
+// @Description Get a single category of the authenticated user
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Category UUID"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.CategoryResponse}
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /categories/{uuid} [get]
+func (cc *categoryController) GetCategory(c *fiber.Ctx) error {
+	category, err := cc.categoryService.GetCategory(c.Locals("user_uuid").(string), c.Params("uuid"))
+	if err != nil {
+		return categoryErrorResponse(c, "Failed to get category", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Category retrieved successfully",
+		Data:    category,
+	})
+}
+
+// UpdateCategory godoc
+// @Summary Update a category
+// @Description Update the name, color and icon of a category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Category UUID"
+// @Param request body dtos.CategoryRequest true "Category data"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.CategoryResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /categories/{uuid} [put]
+func (cc *categoryController) UpdateCategory(c *fiber.Ctx) error {
+	var request dtos.CategoryRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	request.UserUUID = c.Locals("user_uuid").(string)
+
+	category, err := cc.categoryService.UpdateCategory(c.Params("uuid"), &request)
+	if err != nil {
+		return categoryErrorResponse(c, "Failed to update category", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Category updated successfully",
+		Data:    category,
+	})
+}
+
+// DeleteCategory godoc
+// @Summary Delete a category
+// @Description Delete a category, savings in it become uncategorized
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Category UUID"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /categories/{uuid} [delete]
+func (cc *categoryController) DeleteCategory(c *fiber.Ctx) error {
+	if err := cc.categoryService.DeleteCategory(c.Locals("user_uuid").(string), c.Params("uuid")); err != nil {
+		return categoryErrorResponse(c, "Failed to delete category", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Category deleted successfully",
+	})
+}
+
+// categoryErrorResponse maps category errors to the matching HTTP status
+func categoryErrorResponse(c *fiber.Ctx, message string, err error) error {
+	var validationErr *validator.ValidationError
+
+	code := fiber.StatusInternalServerError
+	switch {
+	case errors.As(err, &validationErr):
+		code = fiber.StatusBadRequest
+	case errors.Is(err, repositories.ErrCategoryNotFound):
+		code = fiber.StatusNotFound
+	case errors.Is(err, repositories.ErrCategoryExists):
+		code = fiber.StatusConflict
+	}
+
+	return c.Status(code).JSON(dtos.ErrorResponseDTO{
+		Success: false,
+		Message: message,
+		Code:    code,
+		Errors:  err.Error(),
+	})
+}
+
+// Router implements CategoryController.
+func (cc *categoryController) Router(router fiber.Router) {
+	withMiddleware := router.Use(jwt.JwtMiddleware(cc.userService, cc.redisService))
+	{
+		withMiddleware.Post("/", jwt.RequireScope(services.ScopeSavingsWrite), cc.CreateCategory)
+		withMiddleware.Get("/", jwt.RequireScope(services.ScopeSavingsRead), cc.GetCategories)
+		withMiddleware.Get("/:uuid", jwt.RequireScope(services.ScopeSavingsRead), cc.GetCategory)
+		withMiddleware.Put("/:uuid", jwt.RequireScope(services.ScopeSavingsWrite), cc.UpdateCategory)
+		withMiddleware.Delete("/:uuid", jwt.RequireScope(services.ScopeSavingsWrite), cc.DeleteCategory)
+	}
+}
+
+func NewCategoryController(categoryService services.CategoryService, redisService services.RedisService, userService services.UserService) CategoryController {
+	return &categoryController{categoryService: categoryService, redisService: redisService, userService: userService}
+}
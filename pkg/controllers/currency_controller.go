@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/middleware/jwt"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/services"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+type CurrencyController interface {
+	Router(router fiber.Router)
+	GetCurrencies(c *fiber.Ctx) error
+	CreateCurrency(c *fiber.Ctx) error
+	UpdateCurrency(c *fiber.Ctx) error
+	DeleteCurrency(c *fiber.Ctx) error
+}
+
+type currencyController struct {
+	currencyService services.CurrencyService
+	rbacService     services.RbacService
+	redisService    services.RedisService
+	userService     services.UserService
+}
+
+// GetCurrencies godoc
+// @Summary Get currencies
+// @Description Get every currency that can be used for savings
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dtos.SuccessResponse{data=[]dtos.CurrencyResponse}
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/currencies [get]
+func (cc *currencyController) GetCurrencies(c *fiber.Ctx) error {
+	currencies, err := cc.currencyService.GetCurrencies()
+	if err != nil {
+		return currencyErrorResponse(c, "Failed to get currencies", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Currencies retrieved successfully",
+		Data:    currencies,
+	})
+}
+
+// CreateCurrency godoc
+// @Summary Create a currency
+// @Description Add a currency that can be used for savings
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dtos.CurrencyRequest true "Currency data"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.CurrencyResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/currencies [post]
+func (cc *currencyController) CreateCurrency(c *fiber.Ctx) error {
+	var request dtos.CurrencyRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	currency, err := cc.currencyService.CreateCurrency(&request)
+	if err != nil {
+		return currencyErrorResponse(c, "Failed to create currency", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Currency created successfully",
+		Data:    currency,
+	})
+}
+
+// UpdateCurrency godoc
+// @Summary Update a currency
+// @Description Update a currency. The code can only be changed while no saving uses it.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Currency UUID"
+// @Param request body dtos.CurrencyRequest true "Currency data"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.CurrencyResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/currencies/{uuid} [put]
+func (cc *currencyController) UpdateCurrency(c *fiber.Ctx) error {
+	var request dtos.CurrencyRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	currency, err := cc.currencyService.UpdateCurrency(c.Params("uuid"), &request)
+	if err != nil {
+		return currencyErrorResponse(c, "Failed to update currency", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Currency updated successfully",
+		Data:    currency,
+	})
+}
+
+// DeleteCurrency godoc
+// @Summary Delete a currency
+// @Description Delete a currency that is not used by any saving
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Currency UUID"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /admin/currencies/{uuid} [delete]
+func (cc *currencyController) DeleteCurrency(c *fiber.Ctx) error {
+	if err := cc.currencyService.DeleteCurrency(c.Params("uuid")); err != nil {
+		return currencyErrorResponse(c, "Failed to delete currency", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Currency deleted successfully",
+	})
+}
+
+// currencyErrorResponse maps currency errors to the matching HTTP status
+func currencyErrorResponse(c *fiber.Ctx, message string, err error) error {
+	var validationErr *validator.ValidationError
+
+	code := fiber.StatusInternalServerError
+	switch {
+	case errors.As(err, &validationErr):
+		code = fiber.StatusBadRequest
+	case errors.Is(err, repositories.ErrCurrencyNotFound):
+		code = fiber.StatusNotFound
+	case errors.Is(err, repositories.ErrCurrencyExists), errors.Is(err, repositories.ErrCurrencyInUse):
+		code = fiber.StatusConflict
+	}
+
+	return c.Status(code).JSON(dtos.ErrorResponseDTO{
+		Success: false,
+		Message: message,
+		Code:    code,
+		Errors:  err.Error(),
+	})
+}
+
+// Router implements CurrencyController.
+func (cc *currencyController) Router(router fiber.Router) {
+	withMiddleware := router.Use(
+		jwt.JwtMiddleware(cc.userService, cc.redisService),
+		jwt.RequireScope(services.ScopeAccount),
+		jwt.RequirePermission(cc.rbacService, services.PermissionCurrenciesWrite),
+	)
+	{
+		withMiddleware.Get("/", cc.GetCurrencies)
+		withMiddleware.Post("/", cc.CreateCurrency)
+		withMiddleware.Put("/:uuid", cc.UpdateCurrency)
+		withMiddleware.Delete("/:uuid", cc.DeleteCurrency)
+	}
+}
+
+func NewCurrencyController(currencyService services.CurrencyService, rbacService services.RbacService, redisService services.RedisService, userService services.UserService) CurrencyController {
+	return &currencyController{currencyService: currencyService, rbacService: rbacService, redisService: redisService, userService: userService}
+}
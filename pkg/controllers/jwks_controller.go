@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/services"
+)
+
+type JwksController interface {
+	Router(router fiber.Router)
+	GetJwks(c *fiber.Ctx) error
+}
+
+type jwksController struct {
+	jwtService services.JwtService
+}
+
+// GetJwks godoc
+// @Summary Get JSON Web Key Set
+// @Description Get the public keys other services use to verify access tokens, served at /.well-known/jwks.json
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dtos.JwksResponse
+// @Router /.well-known/jwks.json [get]
+func (jc *jwksController) GetJwks(c *fiber.Ctx) error {
+	// Key set dikembalikan apa adanya sesuai RFC 7517, bukan dibungkus SuccessResponse
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(jc.jwtService.Jwks())
+}
+
+// Router implements JwksController.
+func (jc *jwksController) Router(router fiber.Router) {
+	router.Get("/jwks.json", jc.GetJwks)
+}
+
+func NewJwksController(jwtService services.JwtService) JwksController {
+	return &jwksController{jwtService: jwtService}
+}
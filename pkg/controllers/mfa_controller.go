@@ -0,0 +1,203 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/middleware/jwt"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/services"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+type MfaController interface {
+	Router(router fiber.Router)
+	Enroll(c *fiber.Ctx) error
+	Confirm(c *fiber.Ctx) error
+	Disable(c *fiber.Ctx) error
+	Verify(c *fiber.Ctx) error
+}
+
+type mfaController struct {
+	mfaService   services.MfaService
+	userService  services.UserService
+	redisService services.RedisService
+}
+
+// Enroll godoc
+// @Summary Start two-factor enrollment
+// @Description Generate a new TOTP secret. Scan the otpauth URI or the base64 PNG QR code with an authenticator app, then confirm it with a code.
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.MfaEnrollResponse}
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /auth/mfa/enroll [post]
+func (mc *mfaController) Enroll(c *fiber.Ctx) error {
+	enrollment, err := mc.mfaService.Enroll(c.Locals("user").(*models.User))
+	if err != nil {
+		return mfaErrorResponse(c, "Failed to start two-factor enrollment", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Scan the QR code with your authenticator app",
+		Data:    enrollment,
+	})
+}
+
+// Confirm godoc
+// @Summary Confirm two-factor enrollment
+// @Description Enable two-factor authentication with a code from the authenticator app. The recovery codes are only shown once.
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dtos.MfaCodeRequest true "Authenticator code"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.MfaRecoveryCodesResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 429 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /auth/mfa/confirm [post]
+func (mc *mfaController) Confirm(c *fiber.Ctx) error {
+	var request dtos.MfaCodeRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	recoveryCodes, err := mc.mfaService.Confirm(c.Locals("user").(*models.User), &request)
+	if err != nil {
+		return mfaErrorResponse(c, "Failed to enable two-factor authentication", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Two-factor authentication enabled, store the recovery codes somewhere safe",
+		Data:    recoveryCodes,
+	})
+}
+
+// Disable godoc
+// @Summary Disable two-factor authentication
+// @Description Disable two-factor authentication with a code from the authenticator app or a recovery code
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dtos.MfaCodeRequest true "Authenticator or recovery code"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 429 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /auth/mfa/disable [post]
+func (mc *mfaController) Disable(c *fiber.Ctx) error {
+	var request dtos.MfaCodeRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	if err := mc.mfaService.Disable(c.Locals("user").(*models.User), &request); err != nil {
+		return mfaErrorResponse(c, "Failed to disable two-factor authentication", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Two-factor authentication disabled",
+		Data:    nil,
+	})
+}
+
+// Verify godoc
+// @Summary Complete a two-factor login
+// @Description Exchange the mfa_token returned by login and an authenticator or recovery code for the access and refresh tokens
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param request body dtos.MfaVerifyRequest true "MFA token and code"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.LoginResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 401 {object} dtos.ErrorResponseDTO
+// @Failure 429 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /auth/mfa/verify [post]
+func (mc *mfaController) Verify(c *fiber.Ctx) error {
+	var request dtos.MfaVerifyRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	request.UserAgent = c.Get(fiber.HeaderUserAgent)
+	request.IPAddress = c.IP()
+
+	response, err := mc.userService.VerifyMfa(&request)
+	if err != nil {
+		return mfaErrorResponse(c, "Failed to verify two-factor code", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Login successful",
+		Data:    response,
+	})
+}
+
+// mfaErrorResponse maps two-factor errors to the matching HTTP status
+func mfaErrorResponse(c *fiber.Ctx, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	var validationErr *validator.ValidationError
+	switch {
+	case errors.As(err, &validationErr),
+		errors.Is(err, services.ErrMfaInvalidCode),
+		errors.Is(err, services.ErrMfaNotEnrolled),
+		errors.Is(err, services.ErrMfaNotEnabled):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, services.ErrInvalidToken):
+		status = fiber.StatusUnauthorized
+	case errors.Is(err, repositories.ErrMfaAlreadyEnabled):
+		status = fiber.StatusConflict
+	case errors.Is(err, services.ErrMfaTooManyAttempts):
+		status = fiber.StatusTooManyRequests
+	}
+
+	return c.Status(status).JSON(dtos.ErrorResponseDTO{
+		Success: false,
+		Message: message,
+		Code:    status,
+		Errors:  err.Error(),
+	})
+}
+
+// Router implements MfaController.
+func (mc *mfaController) Router(router fiber.Router) {
+	router.Post("/verify", mc.Verify)
+
+	authenticated := jwt.JwtMiddleware(mc.userService, mc.redisService)
+	router.Post("/enroll", authenticated, jwt.RequireScope(services.ScopeAccount), mc.Enroll)
+	router.Post("/confirm", authenticated, jwt.RequireScope(services.ScopeAccount), mc.Confirm)
+	router.Post("/disable", authenticated, jwt.RequireScope(services.ScopeAccount), mc.Disable)
+}
+
+func NewMfaController(mfaService services.MfaService, userService services.UserService, redisService services.RedisService) MfaController {
+	return &mfaController{mfaService: mfaService, userService: userService, redisService: redisService}
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/services"
+)
+
+type OidcController interface {
+	Router(router fiber.Router)
+	Authorize(c *fiber.Ctx) error
+	Callback(c *fiber.Ctx) error
+}
+
+type oidcController struct {
+	oidcService services.OidcService
+	userService services.UserService
+}
+
+// Authorize godoc
+// @Summary Start social login
+// @Description Get the authorization URL of an OpenID Connect provider such as google or apple. Open it in a browser, the provider redirects back to the callback.
+// @Tags Authentication
+// @Produce json
+// @Param provider path string true "Provider name from the config"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.OidcAuthorizationResponse}
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 502 {object} dtos.ErrorResponseDTO
+// @Router /auth/oidc/{provider} [get]
+func (oc *oidcController) Authorize(c *fiber.Ctx) error {
+	response, err := oc.oidcService.AuthorizationUrl(c.Params("provider"))
+	if err != nil {
+		return oidcErrorResponse(c, "Failed to start login", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Authorization url created",
+		Data:    response,
+	})
+}
+
+// Callback godoc
+// @Summary Finish social login
+// @Description Exchange the authorization code for tokens. An account with the same verified email is linked, otherwise a new account is created.
+// @Description An existing account whose email is not verified is not linked, the request fails with 409.
+// @Description When two-factor authentication is enabled, mfa_required is true and the mfa_token must be sent to /auth/mfa/verify.
+// @Tags Authentication
+// @Produce json
+// @Param provider path string true "Provider name from the config"
+// @Param code query string true "Authorization code"
+// @Param state query string true "State from the authorization url"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.LoginResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 401 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 502 {object} dtos.ErrorResponseDTO
+// @Router /auth/oidc/{provider}/callback [get]
+func (oc *oidcController) Callback(c *fiber.Ctx) error {
+	var request dtos.OidcCallbackRequest
+
+	// Apple mengirim callback sebagai form_post, provider lain lewat query string
+	var err error
+	if c.Method() == fiber.MethodPost {
+		err = c.BodyParser(&request)
+	} else {
+		err = c.QueryParser(&request)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	request.Provider = c.Params("provider")
+	request.UserAgent = c.Get(fiber.HeaderUserAgent)
+	request.IPAddress = c.IP()
+
+	response, err := oc.userService.LoginWithOidc(&request)
+	if err != nil {
+		return oidcErrorResponse(c, "Failed to login", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Login successful",
+		Data:    response,
+	})
+}
+
+// oidcErrorResponse maps social login errors to the matching HTTP status
+func oidcErrorResponse(c *fiber.Ctx, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrOidcProviderNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, services.ErrOidcStateInvalid):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, services.ErrOidcTokenInvalid), errors.Is(err, services.ErrOidcEmailNotVerified):
+		status = fiber.StatusUnauthorized
+	case errors.Is(err, services.ErrAccountDisabled):
+		status = fiber.StatusForbidden
+	case errors.Is(err, services.ErrOidcAccountUnverified):
+		status = fiber.StatusConflict
+	case errors.Is(err, services.ErrOidcProvider):
+		status = fiber.StatusBadGateway
+	}
+
+	return c.Status(status).JSON(dtos.ErrorResponseDTO{
+		Success: false,
+		Message: message,
+		Code:    status,
+		Errors:  err.Error(),
+	})
+}
+
+// Router implements OidcController.
+func (oc *oidcController) Router(router fiber.Router) {
+	router.Get("/:provider", oc.Authorize)
+	router.Get("/:provider/callback", oc.Callback)
+	router.Post("/:provider/callback", oc.Callback)
+}
+
+func NewOidcController(oidcService services.OidcService, userService services.UserService) OidcController {
+	return &oidcController{oidcService: oidcService, userService: userService}
+}
@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/middleware/jwt"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/services"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+type ProfileController interface {
+	Router(router fiber.Router)
+	GetProfile(c *fiber.Ctx) error
+	UpdateProfile(c *fiber.Ctx) error
+	ChangePassword(c *fiber.Ctx) error
+	RequestEmailChange(c *fiber.Ctx) error
+	ConfirmEmailChange(c *fiber.Ctx) error
+}
+
+type profileController struct {
+	profileService services.ProfileService
+	userService    services.UserService
+	redisService   services.RedisService
+}
+
+// GetProfile godoc
+// @Summary Get profile
+// @Description Get the profile of the logged in user
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.UserResponse}
+// @Failure 401 {object} dtos.ErrorResponseDTO
+// @Router /me [get]
+func (pc *profileController) GetProfile(c *fiber.Ctx) error {
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Profile retrieved successfully",
+		Data:    pc.profileService.GetProfile(c.Locals("user").(*models.User)),
+	})
+}
+
+// UpdateProfile godoc
+// @Summary Update profile
+// @Description Update the name, phone number or photo. Only the fields that are sent are changed, a new phone number has to be verified again.
+// @Tags profile
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param name formData string false "Full name"
+// @Param phone_number formData string false "Phone number"
+// @Param image formData file false "Profile photo"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.UserResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me [patch]
+func (pc *profileController) UpdateProfile(c *fiber.Ctx) error {
+	var request dtos.UpdateProfileRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	image, err := saveUploadedImage(c, "image")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Failed to save file",
+			Code:    fiber.StatusInternalServerError,
+			Errors:  err.Error(),
+		})
+	}
+	if image != "" {
+		request.Photo = &image
+	}
+
+	profile, err := pc.profileService.UpdateProfile(c.Locals("user").(*models.User), &request)
+	if err != nil {
+		return profileErrorResponse(c, "Failed to update profile", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Profile updated successfully",
+		Data:    profile,
+	})
+}
+
+// ChangePassword godoc
+// @Summary Change password
+// @Description Change the password after checking the current one. Every other session is logged out.
+// @Description The new password must follow the configured password policy, a rejected password returns 400 listing the broken rules.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dtos.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 400 {object} dtos.ErrorResponseDTO "Invalid request, wrong current password or password rejected by the policy"
+// @Failure 429 {object} dtos.ErrorResponseDTO "Too many wrong current passwords"
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me/password [post]
+func (pc *profileController) ChangePassword(c *fiber.Ctx) error {
+	var request dtos.ChangePasswordRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	if err := pc.profileService.ChangePassword(c.Locals("user").(*models.User), c.Locals("tokens").(string), &request); err != nil {
+		return profileErrorResponse(c, "Failed to change password", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Password changed, other sessions have been logged out",
+	})
+}
+
+// RequestEmailChange godoc
+// @Summary Change email
+// @Description Send a confirmation link to the new email address. The email only changes after the link is opened.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dtos.ChangeEmailRequest true "New email and current password"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 429 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me/email [post]
+func (pc *profileController) RequestEmailChange(c *fiber.Ctx) error {
+	var request dtos.ChangeEmailRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	if err := pc.profileService.RequestEmailChange(c.Locals("user").(*models.User), &request); err != nil {
+		return profileErrorResponse(c, "Failed to change email", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Confirmation link sent to the new email address",
+	})
+}
+
+// ConfirmEmailChange godoc
+// @Summary Confirm email change
+// @Description Switch to the new email address using the token from the confirmation email
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param token query string true "Email change token"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 409 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me/email/confirm [get]
+func (pc *profileController) ConfirmEmailChange(c *fiber.Ctx) error {
+	if err := pc.profileService.ConfirmEmailChange(c.Query("token")); err != nil {
+		return profileErrorResponse(c, "Failed to confirm email change", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Email changed successfully",
+	})
+}
+
+// profileErrorResponse maps profile errors to the matching HTTP status
+func profileErrorResponse(c *fiber.Ctx, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	var validationErr *validator.ValidationError
+	switch {
+	case errors.As(err, &validationErr),
+		errors.Is(err, services.ErrCurrentPasswordInvalid),
+		errors.Is(err, services.ErrEmailUnchanged),
+		errors.Is(err, repositories.ErrEmailChangeTokenInvalid):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, repositories.ErrUserNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, repositories.ErrEmailTaken), errors.Is(err, repositories.ErrPhoneNumberTaken):
+		status = fiber.StatusConflict
+	case errors.Is(err, services.ErrEmailChangeRateLimited), errors.Is(err, services.ErrPasswordCheckLimited):
+		status = fiber.StatusTooManyRequests
+	}
+
+	return c.Status(status).JSON(dtos.ErrorResponseDTO{
+		Success: false,
+		Message: message,
+		Code:    status,
+		Errors:  err.Error(),
+	})
+}
+
+// Router implements ProfileController.
+// Mounted on /me itself, so the middleware is added per route and does not run for the other /me groups.
+func (pc *profileController) Router(router fiber.Router) {
+	router.Get("/email/confirm", pc.ConfirmEmailChange)
+
+	authenticated := jwt.JwtMiddleware(pc.userService, pc.redisService)
+	router.Get("/", authenticated, pc.GetProfile)
+	router.Patch("/", authenticated, jwt.RequireScope(services.ScopeAccount), pc.UpdateProfile)
+	router.Post("/password", authenticated, jwt.RequireScope(services.ScopeAccount), pc.ChangePassword)
+	router.Post("/email", authenticated, jwt.RequireScope(services.ScopeAccount), pc.RequestEmailChange)
+}
+
+func NewProfileController(profileService services.ProfileService, userService services.UserService, redisService services.RedisService) ProfileController {
+	return &profileController{profileService: profileService, userService: userService, redisService: redisService}
+}
@@ -0,0 +1,215 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/middleware/jwt"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/services"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+type RuleController interface {
+	Router(router fiber.Router)
+	CreateRule(c *fiber.Ctx) error
+	GetRules(c *fiber.Ctx) error
+	UpdateRule(c *fiber.Ctx) error
+	DeleteRule(c *fiber.Ctx) error
+	GetRuleExecutions(c *fiber.Ctx) error
+}
+
+type ruleController struct {
+	ruleService  services.RuleService
+	redisService services.RedisService
+	userService  services.UserService
+}
+
+// CreateRule godoc
+// @Summary Create a saving rule
+// @Description Create an automatic saving rule. round_up rounds every expense up to the next round_to and saves the difference,
+// @Description percentage saves a percentage of every income.
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dtos.SavingRuleRequest true "Rule data"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.SavingRuleResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /rules [post]
+func (r *ruleController) CreateRule(c *fiber.Ctx) error {
+	var request dtos.SavingRuleRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	request.UserUUID = c.Locals("user_uuid").(string)
+
+	rule, err := r.ruleService.CreateRule(&request)
+	if err != nil {
+		return ruleErrorResponse(c, "Failed to create rule", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Rule created successfully",
+		Data:    rule,
+	})
+}
+
+// GetRules godoc
+// @Summary Get saving rules
+// @Description Get all saving rules of the authenticated user
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dtos.SuccessResponse{data=[]dtos.SavingRuleResponse}
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /rules [get]
+func (r *ruleController) GetRules(c *fiber.Ctx) error {
+	rules, err := r.ruleService.GetRules(c.Locals("user_uuid").(string))
+	if err != nil {
+		return ruleErrorResponse(c, "Failed to get rules", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Rules retrieved successfully",
+		Data:    rules,
+	})
+}
+
+// UpdateRule godoc
+// @Summary Update a saving rule
+// @Description Update a saving rule, set is_active to false to pause it
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Rule UUID"
+// @Param request body dtos.SavingRuleRequest true "Rule data"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.SavingRuleResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /rules/{uuid} [put]
+func (r *ruleController) UpdateRule(c *fiber.Ctx) error {
+	var request dtos.SavingRuleRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	request.UserUUID = c.Locals("user_uuid").(string)
+
+	rule, err := r.ruleService.UpdateRule(c.Params("uuid"), &request)
+	if err != nil {
+		return ruleErrorResponse(c, "Failed to update rule", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Rule updated successfully",
+		Data:    rule,
+	})
+}
+
+// DeleteRule godoc
+// @Summary Delete a saving rule
+// @Description Delete a saving rule, deposits it already made are kept
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Rule UUID"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /rules/{uuid} [delete]
+func (r *ruleController) DeleteRule(c *fiber.Ctx) error {
+	if err := r.ruleService.DeleteRule(c.Locals("user_uuid").(string), c.Params("uuid")); err != nil {
+		return ruleErrorResponse(c, "Failed to delete rule", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Rule deleted successfully",
+	})
+}
+
+// GetRuleExecutions godoc
+// @Summary Get rule executions
+// @Description Get the audit trail of a rule, linking every generated deposit to its source cashflow event
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Rule UUID"
+// @Success 200 {object} dtos.SuccessResponse{data=[]dtos.RuleExecutionResponse}
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /rules/{uuid}/executions [get]
+func (r *ruleController) GetRuleExecutions(c *fiber.Ctx) error {
+	executions, err := r.ruleService.GetRuleExecutions(c.Locals("user_uuid").(string), c.Params("uuid"))
+	if err != nil {
+		return ruleErrorResponse(c, "Failed to get rule executions", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Rule executions retrieved successfully",
+		Data:    executions,
+	})
+}
+
+// ruleErrorResponse maps rule and cashflow errors to the matching HTTP status
+func ruleErrorResponse(c *fiber.Ctx, message string, err error) error {
+	var validationErr *validator.ValidationError
+
+	code := fiber.StatusInternalServerError
+	switch {
+	case errors.As(err, &validationErr):
+		code = fiber.StatusBadRequest
+	case errors.Is(err, repositories.ErrRuleNotFound), errors.Is(err, repositories.ErrSavingNotFound):
+		code = fiber.StatusNotFound
+	case errors.Is(err, repositories.ErrCashflowExists):
+		code = fiber.StatusConflict
+	}
+
+	return c.Status(code).JSON(dtos.ErrorResponseDTO{
+		Success: false,
+		Message: message,
+		Code:    code,
+		Errors:  err.Error(),
+	})
+}
+
+// Router implements RuleController.
+func (r *ruleController) Router(router fiber.Router) {
+	withMiddleware := router.Use(jwt.JwtMiddleware(r.userService, r.redisService))
+	{
+		withMiddleware.Post("/", jwt.RequireScope(services.ScopeSavingsWrite), r.CreateRule)
+		withMiddleware.Get("/", jwt.RequireScope(services.ScopeSavingsRead), r.GetRules)
+		withMiddleware.Put("/:uuid", jwt.RequireScope(services.ScopeSavingsWrite), r.UpdateRule)
+		withMiddleware.Delete("/:uuid", jwt.RequireScope(services.ScopeSavingsWrite), r.DeleteRule)
+		withMiddleware.Get("/:uuid/executions", jwt.RequireScope(services.ScopeSavingsRead), r.GetRuleExecutions)
+	}
+}
+
+func NewRuleController(ruleService services.RuleService, redisService services.RedisService, userService services.UserService) RuleController {
+	return &ruleController{ruleService: ruleService, redisService: redisService, userService: userService}
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,19 +12,31 @@ import (
 
 	"alfredo/tabunganku/pkg/dtos"
 	"alfredo/tabunganku/pkg/middleware/jwt"
+	"alfredo/tabunganku/pkg/repositories"
 	"alfredo/tabunganku/pkg/services"
+	"alfredo/tabunganku/pkg/validator"
 )
 
 type SavingController interface {
 	Router(router fiber.Router)
 	CreateSaving(c *fiber.Ctx) error
 	GetSavings(c *fiber.Ctx) error
+	GetSummary(c *fiber.Ctx) error
+	ReplaceTags(c *fiber.Ctx) error
+	Allocate(c *fiber.Ctx) error
+	CreateTransaction(c *fiber.Ctx) error
+	GetTransactions(c *fiber.Ctx) error
+	ReplaceMilestones(c *fiber.Ctx) error
+	GetTimeline(c *fiber.Ctx) error
 }
 
 type savingController struct {
-	savingService services.SavingService
-	redisService  services.RedisService
-	userService   services.UserService
+	savingService      services.SavingService
+	allocationService  services.AllocationService
+	transactionService services.SavingTransactionService
+	milestoneService   services.MilestoneService
+	redisService       services.RedisService
+	userService        services.UserService
 }
 
 // CreateSaving godoc
@@ -38,9 +51,15 @@ type savingController struct {
 // @Param currency_code formData string true "Currency code (3 characters)" minlength(3) maxlength(3)
 // @Param filling_plan formData string true "Filling plan" Enums(Daily, Weekly, Monthly)
 // @Param filling_nominal formData number true "Filling nominal amount" minimum(0.01)
+// @Param category_uuid formData string false "Category UUID"
+// @Param priority formData integer false "Priority, higher is filled first" minimum(0)
+// @Param deadline formData string false "Deadline (YYYY-MM-DD)"
+// @Param tags formData []string false "Tags" collectionFormat(multi)
 // @Param image formData file true "Image file"
 // @Success 200 {object} dtos.SuccessResponse
 // @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 403 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
 // @Failure 500 {object} dtos.ErrorResponseDTO
 // @Router /savings [post]
 func (s *savingController) CreateSaving(c *fiber.Ctx) error {
@@ -92,12 +111,7 @@ func (s *savingController) CreateSaving(c *fiber.Ctx) error {
 	// Create saving
 	savingResponse, err := s.savingService.CreateSaving(&savingRequest)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dtos.ErrorResponseDTO{
-			Success: false,
-			Message: "Failed to create saving",
-			Code:    fiber.StatusInternalServerError,
-			Errors:  err.Error(),
-		})
+		return savingErrorResponse(c, "Failed to create saving", err)
 	}
 
 	return c.JSON(dtos.SuccessResponse{
@@ -109,27 +123,34 @@ func (s *savingController) CreateSaving(c *fiber.Ctx) error {
 
 // GetSavings godoc
 // @Summary Get user savings
-// @Description Get all savings records for the authenticated user
+// @Description Get all savings records for the authenticated user, optionally filtered by category or tag
 // @Tags savings
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token"
+// @Param category_uuid query string false "Filter by category UUID"
+// @Param tag query string false "Filter by tag"
 // @Success 200 {object} dtos.SuccessResponse
+// @Failure 400 {object} dtos.ErrorResponseDTO
 // @Failure 500 {object} dtos.ErrorResponseDTO
 // @Router /savings [get]
 func (s *savingController) GetSavings(c *fiber.Ctx) error {
-	userUuid := c.Locals("user_uuid").(string)
-	fmt.Println(userUuid)
-	savings, err := s.savingService.GetSavings(userUuid)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dtos.ErrorResponseDTO{
+	var filter dtos.SavingFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
 			Success: false,
-			Message: "Failed to get savings",
-			Code:    fiber.StatusInternalServerError,
+			Message: "Invalid query parameters",
+			Code:    fiber.StatusBadRequest,
 			Errors:  err.Error(),
 		})
 	}
 
+	userUuid := c.Locals("user_uuid").(string)
+	savings, err := s.savingService.GetSavings(userUuid, &filter)
+	if err != nil {
+		return savingErrorResponse(c, "Failed to get savings", err)
+	}
+
 	return c.JSON(dtos.SuccessResponse{
 		Success: true,
 		Message: "Savings retrieved successfully",
@@ -137,15 +158,292 @@ func (s *savingController) GetSavings(c *fiber.Ctx) error {
 	})
 }
 
+// GetSummary godoc
+// @Summary Get savings summary
+// @Description Get the savings totals of the authenticated user grouped per category
+// @Tags savings
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.SavingSummaryResponse}
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /savings/summary [get]
+func (s *savingController) GetSummary(c *fiber.Ctx) error {
+	summary, err := s.savingService.GetSummary(c.Locals("user_uuid").(string))
+	if err != nil {
+		return savingErrorResponse(c, "Failed to get savings summary", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Savings summary retrieved successfully",
+		Data:    summary,
+	})
+}
+
+// ReplaceTags godoc
+// @Summary Replace saving tags
+// @Description Replace all tags of a saving with the given list
+// @Tags savings
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Saving UUID"
+// @Param request body dtos.SavingTagsRequest true "Tags"
+// @Success 200 {object} dtos.SuccessResponse{data=[]string}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /savings/{uuid}/tags [put]
+func (s *savingController) ReplaceTags(c *fiber.Ctx) error {
+	var request dtos.SavingTagsRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	tags, err := s.savingService.ReplaceSavingTags(c.Locals("user_uuid").(string), c.Params("uuid"), &request)
+	if err != nil {
+		return savingErrorResponse(c, "Failed to update saving tags", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Saving tags updated successfully",
+		Data:    tags,
+	})
+}
+
+// Allocate godoc
+// @Summary Allocate a lump-sum deposit
+// @Description Spread an amount across unfinished savings of the same currency, creating one deposit per saving atomically.
+// @Description Strategies: priority (highest priority first), proportional (relative to the remaining amount) and deadline (earliest deadline first).
+// @Description Set preview to true to get the split without saving it.
+// @Tags savings
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dtos.AllocationRequest true "Allocation data"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.AllocationResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /savings/allocate [post]
+func (s *savingController) Allocate(c *fiber.Ctx) error {
+	var request dtos.AllocationRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	request.UserUUID = c.Locals("user_uuid").(string)
+
+	allocation, err := s.allocationService.Allocate(&request)
+	if err != nil {
+		return savingErrorResponse(c, "Failed to allocate deposit", err)
+	}
+
+	message := "Deposit allocated successfully"
+	if allocation.Preview {
+		message = "Allocation preview generated successfully"
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: message,
+		Data:    allocation,
+	})
+}
+
+// CreateTransaction godoc
+// @Summary Post a saving transaction
+// @Description Deposit into or withdraw from a saving. Milestones crossed by a deposit are returned in the response.
+// @Tags savings
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Saving UUID"
+// @Param request body dtos.SavingTransactionRequest true "Transaction data"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.SavingTransactionResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 422 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /savings/{uuid}/transactions [post]
+func (s *savingController) CreateTransaction(c *fiber.Ctx) error {
+	var request dtos.SavingTransactionRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	request.UserUUID = c.Locals("user_uuid").(string)
+	request.SavingUUID = c.Params("uuid")
+
+	transaction, err := s.transactionService.CreateTransaction(&request)
+	if err != nil {
+		return savingErrorResponse(c, "Failed to post transaction", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Transaction posted successfully",
+		Data:    transaction,
+	})
+}
+
+// GetTransactions godoc
+// @Summary Get saving transactions
+// @Description Get the ledger transactions of a saving, newest first
+// @Tags savings
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Saving UUID"
+// @Success 200 {object} dtos.SuccessResponse{data=[]dtos.SavingTransactionResponse}
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /savings/{uuid}/transactions [get]
+func (s *savingController) GetTransactions(c *fiber.Ctx) error {
+	transactions, err := s.transactionService.GetTransactions(c.Locals("user_uuid").(string), c.Params("uuid"))
+	if err != nil {
+		return savingErrorResponse(c, "Failed to get transactions", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Transactions retrieved successfully",
+		Data:    transactions,
+	})
+}
+
+// ReplaceMilestones godoc
+// @Summary Replace saving milestones
+// @Description Replace the milestones of a saving with custom percentages of the target. Savings without custom milestones use 25, 50, 75 and 100.
+// @Tags savings
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Saving UUID"
+// @Param request body dtos.SavingMilestonesRequest true "Milestones"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.SavingTimelineResponse}
+// @Failure 400 {object} dtos.ErrorResponseDTO
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /savings/{uuid}/milestones [put]
+func (s *savingController) ReplaceMilestones(c *fiber.Ctx) error {
+	var request dtos.SavingMilestonesRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	timeline, err := s.milestoneService.ReplaceMilestones(c.Locals("user_uuid").(string), c.Params("uuid"), &request)
+	if err != nil {
+		return savingErrorResponse(c, "Failed to update milestones", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Milestones updated successfully",
+		Data:    timeline,
+	})
+}
+
+// GetTimeline godoc
+// @Summary Get saving timeline
+// @Description Get the progress of a saving with its milestones and the milestone events reached so far
+// @Tags savings
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uuid path string true "Saving UUID"
+// @Success 200 {object} dtos.SuccessResponse{data=dtos.SavingTimelineResponse}
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /savings/{uuid}/timeline [get]
+func (s *savingController) GetTimeline(c *fiber.Ctx) error {
+	timeline, err := s.milestoneService.GetTimeline(c.Locals("user_uuid").(string), c.Params("uuid"))
+	if err != nil {
+		return savingErrorResponse(c, "Failed to get timeline", err)
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Timeline retrieved successfully",
+		Data:    timeline,
+	})
+}
+
+// savingErrorResponse maps saving errors to the matching HTTP status
+func savingErrorResponse(c *fiber.Ctx, message string, err error) error {
+	var validationErr *validator.ValidationError
+
+	code := fiber.StatusInternalServerError
+	switch {
+	case errors.As(err, &validationErr), errors.Is(err, repositories.ErrInvalidDeadline):
+		code = fiber.StatusBadRequest
+	case errors.Is(err, repositories.ErrSavingNotFound), errors.Is(err, repositories.ErrCategoryNotFound),
+		errors.Is(err, repositories.ErrNoAllocationCandidates):
+		code = fiber.StatusNotFound
+	case errors.Is(err, repositories.ErrInsufficientBalance):
+		code = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(code).JSON(dtos.ErrorResponseDTO{
+		Success: false,
+		Message: message,
+		Code:    code,
+		Errors:  err.Error(),
+	})
+}
+
 // Router implements SavingController.
 func (s *savingController) Router(router fiber.Router) {
 	withMiddleware := router.Use(jwt.JwtMiddleware(s.userService, s.redisService))
 	{
-		withMiddleware.Post("/", s.CreateSaving)
-		withMiddleware.Get("/", s.GetSavings)
+		withMiddleware.Post("/", jwt.RequireScope(services.ScopeSavingsWrite), jwt.RequireVerifiedEmail(), s.CreateSaving)
+		withMiddleware.Get("/", jwt.RequireScope(services.ScopeSavingsRead), s.GetSavings)
+		withMiddleware.Get("/summary", jwt.RequireScope(services.ScopeSavingsRead), s.GetSummary)
+		withMiddleware.Post("/allocate", jwt.RequireScope(services.ScopeSavingsWrite), s.Allocate)
+		withMiddleware.Put("/:uuid/tags", jwt.RequireScope(services.ScopeSavingsWrite), s.ReplaceTags)
+		withMiddleware.Post("/:uuid/transactions", jwt.RequireScope(services.ScopeSavingsWrite), s.CreateTransaction)
+		withMiddleware.Get("/:uuid/transactions", jwt.RequireScope(services.ScopeSavingsRead), s.GetTransactions)
+		withMiddleware.Put("/:uuid/milestones", jwt.RequireScope(services.ScopeSavingsWrite), s.ReplaceMilestones)
+		withMiddleware.Get("/:uuid/timeline", jwt.RequireScope(services.ScopeSavingsRead), s.GetTimeline)
 	}
 }
 
-func NewSavingController(savingService services.SavingService, redisService services.RedisService, userService services.UserService) SavingController {
-	return &savingController{savingService: savingService, redisService: redisService, userService: userService}
+func NewSavingController(
+	savingService services.SavingService,
+	allocationService services.AllocationService,
+	transactionService services.SavingTransactionService,
+	milestoneService services.MilestoneService,
+	redisService services.RedisService,
+	userService services.UserService,
+) SavingController {
+	return &savingController{
+		savingService:      savingService,
+		allocationService:  allocationService,
+		transactionService: transactionService,
+		milestoneService:   milestoneService,
+		redisService:       redisService,
+		userService:        userService,
+	}
 }
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/middleware/jwt"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/services"
+)
+
+type SessionController interface {
+	Router(router fiber.Router)
+	GetSessions(c *fiber.Ctx) error
+	RevokeSession(c *fiber.Ctx) error
+}
+
+type sessionController struct {
+	sessionService services.SessionService
+	redisService   services.RedisService
+	userService    services.UserService
+}
+
+// GetSessions godoc
+// @Summary Get active sessions
+// @Description Get every device the authenticated user is logged in on. The session of the current token is flagged with current.
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dtos.SuccessResponse{data=[]dtos.SessionResponse}
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me/sessions [get]
+func (sc *sessionController) GetSessions(c *fiber.Ctx) error {
+	sessions, err := sc.sessionService.GetSessions(c.Locals("user_uuid").(string), c.Locals("tokens").(string))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Failed to get sessions",
+			Code:    fiber.StatusInternalServerError,
+			Errors:  err.Error(),
+		})
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Sessions retrieved successfully",
+		Data:    sessions,
+	})
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Log out a specific device. Its access and refresh tokens stop working immediately.
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Session UUID"
+// @Success 200 {object} dtos.SuccessResponse
+// @Failure 404 {object} dtos.ErrorResponseDTO
+// @Failure 500 {object} dtos.ErrorResponseDTO
+// @Router /me/sessions/{id} [delete]
+func (sc *sessionController) RevokeSession(c *fiber.Ctx) error {
+	if err := sc.sessionService.RevokeSession(c.Locals("user_uuid").(string), c.Params("id")); err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, repositories.ErrSessionNotFound) {
+			status = fiber.StatusNotFound
+		}
+
+		return c.Status(status).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Failed to revoke session",
+			Code:    status,
+			Errors:  err.Error(),
+		})
+	}
+
+	return c.JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Session revoked successfully",
+		Data:    nil,
+	})
+}
+
+// Router implements SessionController.
+func (sc *sessionController) Router(router fiber.Router) {
+	withMiddleware := router.Use(jwt.JwtMiddleware(sc.userService, sc.redisService))
+	{
+		withMiddleware.Get("/", jwt.RequireScope(services.ScopeAccount), sc.GetSessions)
+		withMiddleware.Delete("/:id", jwt.RequireScope(services.ScopeAccount), sc.RevokeSession)
+	}
+}
+
+func NewSessionController(sessionService services.SessionService, redisService services.RedisService, userService services.UserService) SessionController {
+	return &sessionController{sessionService: sessionService, redisService: redisService, userService: userService}
+}
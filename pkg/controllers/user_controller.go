@@ -1,38 +1,63 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
 	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/middleware/jwt"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
 	"alfredo/tabunganku/pkg/services"
+	"alfredo/tabunganku/pkg/validator"
 )
 
 type UserController interface {
 	Router(router fiber.Router)
 	Register(c *fiber.Ctx) error
 	Login(c *fiber.Ctx) error
+	Refresh(c *fiber.Ctx) error
+	Logout(c *fiber.Ctx) error
+	LogoutAll(c *fiber.Ctx) error
+	CreateWebviewToken(c *fiber.Ctx) error
+	ForgotPassword(c *fiber.Ctx) error
+	ResetPassword(c *fiber.Ctx) error
+	VerifyEmail(c *fiber.Ctx) error
+	ResendVerification(c *fiber.Ctx) error
+	SendPhoneOtp(c *fiber.Ctx) error
+	VerifyPhoneOtp(c *fiber.Ctx) error
+	UnlockAccount(c *fiber.Ctx) error
 }
 
 type userControllerImpl struct {
-	redisService services.RedisService
-	userService  services.UserService
+	redisService             services.RedisService
+	userService              services.UserService
+	passwordService          services.PasswordService
+	emailVerificationService services.EmailVerificationService
+	phoneVerificationService services.PhoneVerificationService
 }
 
 // Login godoc
 // @Summary      User Login
-// @Description  Authenticate user with email and password
+// @Description  Authenticate user with email and password. When two-factor authentication is enabled, only an mfa_token is returned and must be exchanged at /auth/mfa/verify.
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
 // @Param        request body dtos.LoginRequest true "Login credentials"
 // @Success      200 {object} dtos.SuccessResponse{data=dtos.LoginResponse} "Login successful"
 // @Failure      400 {object} dtos.ErrorResponseDTO "Invalid request body"
+// @Failure      401 {object} dtos.ErrorResponseDTO "Invalid email or password"
+// @Failure      403 {object} dtos.ErrorResponseDTO "Account disabled"
+// @Failure      423 {object} dtos.ErrorResponseDTO "Account temporarily locked"
+// @Failure      429 {object} dtos.ErrorResponseDTO "Too many failed logins, see Retry-After"
 // @Failure      500 {object} dtos.ErrorResponseDTO "Internal server error"
 // @Router       /auth/login [post]
 func (u *userControllerImpl) Login(c *fiber.Ctx) error {
@@ -47,13 +72,30 @@ func (u *userControllerImpl) Login(c *fiber.Ctx) error {
 		})
 	}
 
+	request.UserAgent = c.Get(fiber.HeaderUserAgent)
+	request.IPAddress = c.IP()
+
 	response, err := u.userService.Login(&request)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
+		status := fiber.StatusInternalServerError
+		var throttled *services.LoginThrottledError
+		switch {
+		case errors.Is(err, services.ErrInvalidCredentials):
+			status = fiber.StatusUnauthorized
+		case errors.Is(err, services.ErrAccountLocked):
+			status = fiber.StatusLocked
+		case errors.Is(err, services.ErrAccountDisabled):
+			status = fiber.StatusForbidden
+		case errors.As(err, &throttled):
+			status = fiber.StatusTooManyRequests
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		}
+
+		return c.Status(status).JSON(
 			dtos.ErrorResponseDTO{
 				Success: false,
 				Message: err.Error(),
-				Code:    fiber.StatusInternalServerError,
+				Code:    status,
 				Errors:  err.Error(),
 			},
 		)
@@ -66,6 +108,414 @@ func (u *userControllerImpl) Login(c *fiber.Ctx) error {
 	})
 }
 
+// Refresh godoc
+// @Summary      Refresh Token
+// @Description  Exchange a refresh token for a new access and refresh token pair.
+// @Description  Every refresh token can only be used once, reusing it revokes every token from the same login.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request body dtos.RefreshTokenRequest true "Refresh token"
+// @Success      200 {object} dtos.SuccessResponse{data=dtos.GenerateTokenResponse} "Token refreshed"
+// @Failure      400 {object} dtos.ErrorResponseDTO "Invalid request body"
+// @Failure      401 {object} dtos.ErrorResponseDTO "Invalid or reused refresh token"
+// @Failure      500 {object} dtos.ErrorResponseDTO "Internal server error"
+// @Router       /auth/refresh [post]
+func (u *userControllerImpl) Refresh(c *fiber.Ctx) error {
+	var request dtos.RefreshTokenRequest
+
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	response, err := u.userService.RefreshToken(&request)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidToken) || errors.Is(err, services.ErrRefreshReused) {
+			status = fiber.StatusUnauthorized
+		} else if request.RefreshToken == "" {
+			status = fiber.StatusBadRequest
+		}
+
+		return c.Status(status).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: err.Error(),
+			Code:    status,
+			Errors:  err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Token refreshed successfully",
+		Data:    response,
+	})
+}
+
+// Logout godoc
+// @Summary      Logout
+// @Description  Revoke the current access token and the refresh token issued with it
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer token"
+// @Success      200 {object} dtos.SuccessResponse "Logout successful"
+// @Failure      401 {object} dtos.ErrorResponseDTO "Unauthorized"
+// @Failure      403 {object} dtos.ErrorResponseDTO "API keys and tokens without the account scope can not log out"
+// @Failure      500 {object} dtos.ErrorResponseDTO "Internal server error"
+// @Router       /auth/logout [post]
+func (u *userControllerImpl) Logout(c *fiber.Ctx) error {
+	if err := u.userService.Logout(c.Locals("token").(string)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Failed to logout",
+			Code:    fiber.StatusInternalServerError,
+			Errors:  err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Logout successful",
+		Data:    nil,
+	})
+}
+
+// LogoutAll godoc
+// @Summary      Logout Everywhere
+// @Description  Revoke every access and refresh token of the user on all devices
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer token"
+// @Success      200 {object} dtos.SuccessResponse "Logged out from all sessions"
+// @Failure      401 {object} dtos.ErrorResponseDTO "Unauthorized"
+// @Failure      403 {object} dtos.ErrorResponseDTO "API keys and tokens without the account scope can not log out"
+// @Failure      500 {object} dtos.ErrorResponseDTO "Internal server error"
+// @Router       /auth/logout-all [post]
+func (u *userControllerImpl) LogoutAll(c *fiber.Ctx) error {
+	if err := u.userService.LogoutAll(c.Locals("user_uuid").(string)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Failed to logout from all sessions",
+			Code:    fiber.StatusInternalServerError,
+			Errors:  err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Logged out from all sessions",
+		Data:    nil,
+	})
+}
+
+// CreateWebviewToken godoc
+// @Summary      Create Webview Token
+// @Description  Exchange the access token for a one-time token that opens a single read-only page with ?token=.
+// @Description  The token expires after one minute and only works for the path it was issued for.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer token"
+// @Param        request body dtos.WebviewTokenRequest true "Page path"
+// @Success      200 {object} dtos.SuccessResponse{data=dtos.WebviewTokenResponse} "Webview token created"
+// @Failure      400 {object} dtos.ErrorResponseDTO "Path not allowed"
+// @Failure      401 {object} dtos.ErrorResponseDTO "Unauthorized"
+// @Failure      500 {object} dtos.ErrorResponseDTO "Internal server error"
+// @Router       /auth/webview-token [post]
+func (u *userControllerImpl) CreateWebviewToken(c *fiber.Ctx) error {
+	var request dtos.WebviewTokenRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	response, err := u.userService.CreateWebviewToken(c.Locals("claims").(*services.TokenClaims), &request)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrWebviewPathNotAllowed):
+			status = fiber.StatusBadRequest
+		case errors.Is(err, services.ErrInvalidToken):
+			status = fiber.StatusUnauthorized
+		}
+
+		return c.Status(status).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Failed to create webview token",
+			Code:    status,
+			Errors:  err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Webview token created",
+		Data:    response,
+	})
+}
+
+// ForgotPassword godoc
+// @Summary      Forgot Password
+// @Description  Send a single-use password reset token to the email address.
+// @Description  The response is the same whether the email is registered or not. One request per address per minute.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request body dtos.ForgotPasswordRequest true "Account email"
+// @Success      200 {object} dtos.SuccessResponse "Reset instructions sent"
+// @Failure      400 {object} dtos.ErrorResponseDTO "Invalid request body"
+// @Failure      429 {object} dtos.ErrorResponseDTO "A reset email was sent to this address less than a minute ago"
+// @Failure      500 {object} dtos.ErrorResponseDTO "Internal server error"
+// @Router       /auth/password/forgot [post]
+func (u *userControllerImpl) ForgotPassword(c *fiber.Ctx) error {
+	var request dtos.ForgotPasswordRequest
+
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	if err := u.passwordService.ForgotPassword(&request); err != nil {
+		return passwordErrorResponse(c, "Failed to send reset instructions", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "If the email is registered, reset instructions have been sent",
+		Data:    nil,
+	})
+}
+
+// ResetPassword godoc
+// @Summary      Reset Password
+// @Description  Set a new password using the token from the reset email. Every existing session is logged out.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request body dtos.ResetPasswordRequest true "Reset token and new password"
+// @Success      200 {object} dtos.SuccessResponse "Password reset successful"
+// @Failure      400 {object} dtos.ErrorResponseDTO "Invalid request body, invalid token or password rejected by the policy"
+// @Failure      500 {object} dtos.ErrorResponseDTO "Internal server error"
+// @Router       /auth/password/reset [post]
+func (u *userControllerImpl) ResetPassword(c *fiber.Ctx) error {
+	var request dtos.ResetPasswordRequest
+
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	if err := u.passwordService.ResetPassword(&request); err != nil {
+		return passwordErrorResponse(c, "Failed to reset password", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Password reset successful, please login again",
+		Data:    nil,
+	})
+}
+
+// VerifyEmail godoc
+// @Summary      Verify Email
+// @Description  Mark the email address as verified using the token from the verification email
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        token query string true "Verification token"
+// @Success      200 {object} dtos.SuccessResponse "Email verified"
+// @Failure      400 {object} dtos.ErrorResponseDTO "Invalid or expired token"
+// @Failure      500 {object} dtos.ErrorResponseDTO "Internal server error"
+// @Router       /auth/verify-email [get]
+func (u *userControllerImpl) VerifyEmail(c *fiber.Ctx) error {
+	if err := u.emailVerificationService.VerifyEmail(c.Query("token")); err != nil {
+		return verificationErrorResponse(c, "Failed to verify email", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Email verified successfully",
+		Data:    nil,
+	})
+}
+
+// ResendVerification godoc
+// @Summary      Resend Verification Email
+// @Description  Send a new verification email. Limited to one per minute and a few per day.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer token"
+// @Success      200 {object} dtos.SuccessResponse "Verification email sent"
+// @Failure      409 {object} dtos.ErrorResponseDTO "Email already verified"
+// @Failure      429 {object} dtos.ErrorResponseDTO "Too many requests"
+// @Failure      500 {object} dtos.ErrorResponseDTO "Internal server error"
+// @Router       /auth/verify-email/resend [post]
+func (u *userControllerImpl) ResendVerification(c *fiber.Ctx) error {
+	if err := u.emailVerificationService.ResendVerification(c.Locals("user").(*models.User)); err != nil {
+		return verificationErrorResponse(c, "Failed to send verification email", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Verification email sent",
+		Data:    nil,
+	})
+}
+
+// SendPhoneOtp godoc
+// @Summary      Send Phone OTP
+// @Description  Send a one-time code to the phone number of the account. A new code can be requested once per minute.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer token"
+// @Success      200 {object} dtos.SuccessResponse "Code sent"
+// @Failure      409 {object} dtos.ErrorResponseDTO "Phone number already verified"
+// @Failure      429 {object} dtos.ErrorResponseDTO "Too many requests"
+// @Failure      500 {object} dtos.ErrorResponseDTO "Internal server error"
+// @Router       /auth/phone/otp [post]
+func (u *userControllerImpl) SendPhoneOtp(c *fiber.Ctx) error {
+	if err := u.phoneVerificationService.SendOtp(c.Locals("user").(*models.User)); err != nil {
+		return verificationErrorResponse(c, "Failed to send verification code", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Verification code sent",
+		Data:    nil,
+	})
+}
+
+// VerifyPhoneOtp godoc
+// @Summary      Verify Phone OTP
+// @Description  Mark the phone number as verified using the code sent by SendPhoneOtp. The code is invalidated after a few wrong attempts.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer token"
+// @Param        request body dtos.VerifyOtpRequest true "Verification code"
+// @Success      200 {object} dtos.SuccessResponse "Phone number verified"
+// @Failure      400 {object} dtos.ErrorResponseDTO "Invalid or expired code"
+// @Failure      409 {object} dtos.ErrorResponseDTO "Phone number already verified"
+// @Failure      429 {object} dtos.ErrorResponseDTO "Too many attempts or the daily limit is reached"
+// @Failure      500 {object} dtos.ErrorResponseDTO "Internal server error"
+// @Router       /auth/phone/verify [post]
+func (u *userControllerImpl) VerifyPhoneOtp(c *fiber.Ctx) error {
+	var request dtos.VerifyOtpRequest
+
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Invalid request body",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	if err := u.phoneVerificationService.VerifyOtp(c.Locals("user").(*models.User), &request); err != nil {
+		return verificationErrorResponse(c, "Failed to verify phone number", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Phone number verified successfully",
+		Data:    nil,
+	})
+}
+
+// UnlockAccount godoc
+// @Summary      Unlock Account
+// @Description  Lift a temporary login lock using the token from the lock notification email
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        token query string true "Unlock token"
+// @Success      200 {object} dtos.SuccessResponse "Account unlocked"
+// @Failure      400 {object} dtos.ErrorResponseDTO "Invalid or expired token"
+// @Router       /auth/unlock [get]
+func (u *userControllerImpl) UnlockAccount(c *fiber.Ctx) error {
+	if err := u.userService.UnlockAccount(c.Query("token")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Failed to unlock account",
+			Code:    fiber.StatusBadRequest,
+			Errors:  err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dtos.SuccessResponse{
+		Success: true,
+		Message: "Account unlocked, you can login again",
+		Data:    nil,
+	})
+}
+
+// verificationErrorResponse maps email and phone verification errors to the matching HTTP status
+func verificationErrorResponse(c *fiber.Ctx, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	var validationErr *validator.ValidationError
+	switch {
+	case errors.As(err, &validationErr),
+		errors.Is(err, repositories.ErrVerificationTokenInvalid),
+		errors.Is(err, services.ErrOtpInvalid),
+		errors.Is(err, services.ErrPhoneNumberMissing):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, services.ErrEmailAlreadyVerified), errors.Is(err, services.ErrPhoneAlreadyVerified):
+		status = fiber.StatusConflict
+	case errors.Is(err, services.ErrVerificationRateLimited),
+		errors.Is(err, services.ErrOtpRateLimited),
+		errors.Is(err, services.ErrOtpTooManyAttempts),
+		errors.Is(err, services.ErrOtpDailyLimit):
+		status = fiber.StatusTooManyRequests
+	}
+
+	return c.Status(status).JSON(dtos.ErrorResponseDTO{
+		Success: false,
+		Message: message,
+		Code:    status,
+		Errors:  err.Error(),
+	})
+}
+
+// passwordErrorResponse maps password flow errors to the matching HTTP status
+func passwordErrorResponse(c *fiber.Ctx, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	var validationErr *validator.ValidationError
+	switch {
+	case errors.As(err, &validationErr), errors.Is(err, repositories.ErrResetTokenInvalid):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, services.ErrPasswordResetRateLimited):
+		status = fiber.StatusTooManyRequests
+	}
+
+	return c.Status(status).JSON(dtos.ErrorResponseDTO{
+		Success: false,
+		Message: message,
+		Code:    status,
+		Errors:  err.Error(),
+	})
+}
+
 // Register godoc
 // @Summary      User Registration
 // @Description  Register a new user with form data including optional image upload
@@ -74,12 +524,12 @@ func (u *userControllerImpl) Login(c *fiber.Ctx) error {
 // @Produce      json
 // @Param        name formData string true "User's full name"
 // @Param        email formData string true "User's email address"
-// @Param        password formData string true "User's password (minimum 6 characters)"
+// @Param        password formData string true "User's password, must follow the configured password policy"
 // @Param        confirmation_password formData string true "Password confirmation (must match password)"
 // @Param        phone_number formData string true "User's phone number"
 // @Param        image formData file false "User's profile image (optional)"
 // @Success      200 {object} dtos.SuccessResponse "User registered successfully"
-// @Failure      400 {object} dtos.ErrorResponseDTO "Invalid request body"
+// @Failure      400 {object} dtos.ErrorResponseDTO "Invalid request body or password rejected by the policy"
 // @Failure      500 {object} dtos.ErrorResponseDTO "Internal server error"
 // @Router       /auth/register [post]
 func (u *userControllerImpl) Register(c *fiber.Ctx) error {
@@ -96,42 +546,30 @@ func (u *userControllerImpl) Register(c *fiber.Ctx) error {
 	}
 
 	// Handle file upload
-	file, err := c.FormFile("image")
-	if err == nil && file != nil {
-		// Create uploads directory if it doesn't exist
-		if err := os.MkdirAll("./uploads", 0755); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(dtos.ErrorResponseDTO{
-				Success: false,
-				Message: "Failed to create upload directory",
-				Code:    fiber.StatusInternalServerError,
-				Errors:  err.Error(),
-			})
-		}
-
-		// Generate unique filename
-		ext := filepath.Ext(file.Filename)
-		filename := fmt.Sprintf("%d_%s%s", time.Now().Unix(), uuid.New().String(), ext)
-		filepath := fmt.Sprintf("./uploads/%s", filename)
-
-		// Save the file
-		if err := c.SaveFile(file, filepath); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(dtos.ErrorResponseDTO{
-				Success: false,
-				Message: "Failed to save file",
-				Code:    fiber.StatusInternalServerError,
-				Errors:  err.Error(),
-			})
-		}
-
+	image, err := saveUploadedImage(c, "image")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "Failed to save file",
+			Code:    fiber.StatusInternalServerError,
+			Errors:  err.Error(),
+		})
+	}
+	if image != "" {
 		// Set the image path in the request
-		request.Image = filepath
+		request.Image = image
 	}
 
 	if err := u.userService.Register(&request); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dtos.ErrorResponseDTO{
+		status := fiber.StatusInternalServerError
+		var validationErr *validator.ValidationError
+		if errors.As(err, &validationErr) {
+			status = fiber.StatusBadRequest
+		}
+		return c.Status(status).JSON(dtos.ErrorResponseDTO{
 			Success: false,
 			Message: err.Error(),
-			Code:    fiber.StatusInternalServerError,
+			Code:    status,
 			Errors:  err.Error(),
 		})
 	}
@@ -143,12 +581,63 @@ func (u *userControllerImpl) Register(c *fiber.Ctx) error {
 	})
 }
 
+// saveUploadedImage stores the uploaded file of the form field in ./uploads and returns its path,
+// or an empty string when no file was sent
+func saveUploadedImage(c *fiber.Ctx, field string) (string, error) {
+	file, err := c.FormFile(field)
+	if err != nil || file == nil {
+		return "", nil
+	}
+
+	// Create uploads directory if it doesn't exist
+	if err := os.MkdirAll("./uploads", 0755); err != nil {
+		return "", fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	// Generate unique filename
+	ext := filepath.Ext(file.Filename)
+	filename := fmt.Sprintf("%d_%s%s", time.Now().Unix(), uuid.New().String(), ext)
+	path := fmt.Sprintf("./uploads/%s", filename)
+
+	if err := c.SaveFile(file, path); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
 // Router implements UserController.
 func (u *userControllerImpl) Router(router fiber.Router) {
 	router.Post("/register", u.Register)
 	router.Post("/login", u.Login)
+	router.Post("/refresh", u.Refresh)
+	router.Post("/password/forgot", u.ForgotPassword)
+	router.Post("/password/reset", u.ResetPassword)
+	router.Get("/verify-email", u.VerifyEmail)
+	router.Get("/unlock", u.UnlockAccount)
+
+	authenticated := jwt.JwtMiddleware(u.userService, u.redisService)
+	// API key tidak punya sesi, jadi tidak bisa logout
+	router.Post("/logout", authenticated, jwt.RequireScope(services.ScopeAccount), u.Logout)
+	router.Post("/logout-all", authenticated, jwt.RequireScope(services.ScopeAccount), u.LogoutAll)
+	router.Post("/verify-email/resend", authenticated, jwt.RequireScope(services.ScopeAccount), u.ResendVerification)
+	router.Post("/phone/otp", authenticated, jwt.RequireScope(services.ScopeAccount), u.SendPhoneOtp)
+	router.Post("/webview-token", authenticated, u.CreateWebviewToken)
+	router.Post("/phone/verify", authenticated, jwt.RequireScope(services.ScopeAccount), u.VerifyPhoneOtp)
 }
 
-func NewUserController(redisService services.RedisService, userService services.UserService) UserController {
-	return &userControllerImpl{redisService: redisService, userService: userService}
+func NewUserController(
+	redisService services.RedisService,
+	userService services.UserService,
+	passwordService services.PasswordService,
+	emailVerificationService services.EmailVerificationService,
+	phoneVerificationService services.PhoneVerificationService,
+) UserController {
+	return &userControllerImpl{
+		redisService:             redisService,
+		userService:              userService,
+		passwordService:          passwordService,
+		emailVerificationService: emailVerificationService,
+		phoneVerificationService: phoneVerificationService,
+	}
 }
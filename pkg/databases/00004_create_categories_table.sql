@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE categories(
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_uuid UUID NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(7) NOT NULL, -- Hex color (#FF8800)
+    icon VARCHAR(50) NOT NULL, -- Icon name used by the app
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    FOREIGN KEY (user_uuid) REFERENCES users(uuid)
+);
+
+-- Create indexing
+CREATE INDEX idx_categories_user_uuid ON categories(user_uuid);
+CREATE INDEX idx_categories_deleted_at ON categories(deleted_at);
+CREATE UNIQUE INDEX idx_categories_user_uuid_name ON categories(user_uuid, name) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS categories;
+DROP INDEX IF EXISTS idx_categories_user_uuid;
+DROP INDEX IF EXISTS idx_categories_deleted_at;
+DROP INDEX IF EXISTS idx_categories_user_uuid_name;
+-- +goose StatementEnd
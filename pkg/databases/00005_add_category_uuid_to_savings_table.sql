@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE savings ADD COLUMN category_uuid UUID DEFAULT NULL REFERENCES categories(uuid) ON DELETE SET NULL;
+
+CREATE INDEX idx_savings_category_uuid ON savings(category_uuid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_savings_category_uuid;
+
+ALTER TABLE savings DROP COLUMN IF EXISTS category_uuid;
+-- +goose StatementEnd
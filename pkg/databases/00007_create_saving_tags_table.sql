@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE saving_tags(
+    saving_uuid UUID NOT NULL,
+    tag_uuid UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (saving_uuid, tag_uuid),
+    FOREIGN KEY (saving_uuid) REFERENCES savings(uuid) ON DELETE CASCADE,
+    FOREIGN KEY (tag_uuid) REFERENCES tags(uuid) ON DELETE CASCADE
+);
+
+-- Create indexing
+CREATE INDEX idx_saving_tags_tag_uuid ON saving_tags(tag_uuid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS saving_tags;
+DROP INDEX IF EXISTS idx_saving_tags_tag_uuid;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE savings ADD COLUMN priority INTEGER NOT NULL DEFAULT 0; -- Higher value is filled first
+ALTER TABLE savings ADD COLUMN deadline DATE DEFAULT NULL;
+
+CREATE INDEX idx_savings_deadline ON savings(deadline);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_savings_deadline;
+
+ALTER TABLE savings DROP COLUMN IF EXISTS deadline;
+ALTER TABLE savings DROP COLUMN IF EXISTS priority;
+-- +goose StatementEnd
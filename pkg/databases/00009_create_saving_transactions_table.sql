@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE saving_transactions(
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    saving_uuid UUID NOT NULL,
+    user_uuid UUID NOT NULL,
+    type VARCHAR(10) NOT NULL CHECK (type IN ('deposit', 'withdrawal')),
+    amount DECIMAL(12, 2) NOT NULL CHECK (amount > 0),
+    source VARCHAR(20) NOT NULL, -- manual, allocation, rule, etc
+    reference_uuid UUID DEFAULT NULL, -- Groups transactions created by the same action
+    note VARCHAR(255) DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    FOREIGN KEY (saving_uuid) REFERENCES savings(uuid),
+    FOREIGN KEY (user_uuid) REFERENCES users(uuid)
+);
+
+-- Create indexing
+CREATE INDEX idx_saving_transactions_saving_uuid ON saving_transactions(saving_uuid);
+CREATE INDEX idx_saving_transactions_user_uuid ON saving_transactions(user_uuid);
+CREATE INDEX idx_saving_transactions_reference_uuid ON saving_transactions(reference_uuid);
+CREATE INDEX idx_saving_transactions_deleted_at ON saving_transactions(deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS saving_transactions;
+DROP INDEX IF EXISTS idx_saving_transactions_saving_uuid;
+DROP INDEX IF EXISTS idx_saving_transactions_user_uuid;
+DROP INDEX IF EXISTS idx_saving_transactions_reference_uuid;
+DROP INDEX IF EXISTS idx_saving_transactions_deleted_at;
+-- +goose StatementEnd
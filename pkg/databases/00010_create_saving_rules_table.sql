@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE saving_rules(
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_uuid UUID NOT NULL,
+    saving_uuid UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('round_up', 'percentage')),
+    round_to DECIMAL(12, 2) DEFAULT NULL, -- Used by round_up, e.g. 10000
+    percentage DECIMAL(5, 2) DEFAULT NULL, -- Used by percentage, e.g. 10.00
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    FOREIGN KEY (user_uuid) REFERENCES users(uuid),
+    FOREIGN KEY (saving_uuid) REFERENCES savings(uuid)
+);
+
+-- Create indexing
+CREATE INDEX idx_saving_rules_user_uuid ON saving_rules(user_uuid);
+CREATE INDEX idx_saving_rules_saving_uuid ON saving_rules(saving_uuid);
+CREATE INDEX idx_saving_rules_deleted_at ON saving_rules(deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS saving_rules;
+DROP INDEX IF EXISTS idx_saving_rules_user_uuid;
+DROP INDEX IF EXISTS idx_saving_rules_saving_uuid;
+DROP INDEX IF EXISTS idx_saving_rules_deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE cashflow_events(
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_uuid UUID NOT NULL,
+    type VARCHAR(10) NOT NULL CHECK (type IN ('income', 'expense')),
+    amount DECIMAL(12, 2) NOT NULL CHECK (amount > 0),
+    currency_code VARCHAR(3) NOT NULL,
+    description VARCHAR(255) DEFAULT NULL,
+    external_id VARCHAR(100) DEFAULT NULL, -- Id from the source app, used to ignore duplicates
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_uuid) REFERENCES users(uuid)
+);
+
+-- Create indexing
+CREATE INDEX idx_cashflow_events_user_uuid ON cashflow_events(user_uuid);
+CREATE UNIQUE INDEX idx_cashflow_events_user_uuid_external_id ON cashflow_events(user_uuid, external_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS cashflow_events;
+DROP INDEX IF EXISTS idx_cashflow_events_user_uuid;
+DROP INDEX IF EXISTS idx_cashflow_events_user_uuid_external_id;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE rule_executions(
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    rule_uuid UUID NOT NULL,
+    cashflow_event_uuid UUID NOT NULL,
+    saving_transaction_uuid UUID NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (rule_uuid) REFERENCES saving_rules(uuid),
+    FOREIGN KEY (cashflow_event_uuid) REFERENCES cashflow_events(uuid),
+    FOREIGN KEY (saving_transaction_uuid) REFERENCES saving_transactions(uuid)
+);
+
+-- Create indexing
+CREATE UNIQUE INDEX idx_rule_executions_rule_uuid_cashflow_event_uuid ON rule_executions(rule_uuid, cashflow_event_uuid);
+CREATE INDEX idx_rule_executions_saving_transaction_uuid ON rule_executions(saving_transaction_uuid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rule_executions;
+DROP INDEX IF EXISTS idx_rule_executions_rule_uuid_cashflow_event_uuid;
+DROP INDEX IF EXISTS idx_rule_executions_saving_transaction_uuid;
+-- +goose StatementEnd
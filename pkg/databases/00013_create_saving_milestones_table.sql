@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE saving_milestones(
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    saving_uuid UUID NOT NULL,
+    percentage DECIMAL(5, 2) NOT NULL CHECK (percentage > 0 AND percentage <= 100),
+    label VARCHAR(100) DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (saving_uuid) REFERENCES savings(uuid) ON DELETE CASCADE
+);
+
+-- Create indexing
+CREATE UNIQUE INDEX idx_saving_milestones_saving_uuid_percentage ON saving_milestones(saving_uuid, percentage);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS saving_milestones;
+DROP INDEX IF EXISTS idx_saving_milestones_saving_uuid_percentage;
+-- +goose StatementEnd
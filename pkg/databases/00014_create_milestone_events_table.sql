@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE milestone_events(
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    saving_uuid UUID NOT NULL,
+    user_uuid UUID NOT NULL,
+    percentage DECIMAL(5, 2) NOT NULL,
+    label VARCHAR(100) NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL, -- Balance threshold that was crossed
+    saving_transaction_uuid UUID NOT NULL,
+    reached_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (saving_uuid) REFERENCES savings(uuid) ON DELETE CASCADE,
+    FOREIGN KEY (user_uuid) REFERENCES users(uuid),
+    FOREIGN KEY (saving_transaction_uuid) REFERENCES saving_transactions(uuid)
+);
+
+-- Create indexing
+CREATE UNIQUE INDEX idx_milestone_events_saving_uuid_percentage ON milestone_events(saving_uuid, percentage);
+CREATE INDEX idx_milestone_events_user_uuid ON milestone_events(user_uuid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS milestone_events;
+DROP INDEX IF EXISTS idx_milestone_events_saving_uuid_percentage;
+DROP INDEX IF EXISTS idx_milestone_events_user_uuid;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE badges(
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    icon VARCHAR(50) NOT NULL,
+    event VARCHAR(50) NOT NULL, -- Domain event that triggers the check (saving_created, transaction_posted)
+    metric VARCHAR(50) NOT NULL, -- Value compared to the threshold (deposits_count, savings_completed, etc)
+    threshold DECIMAL(12, 2) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexing
+CREATE UNIQUE INDEX idx_badges_code ON badges(code);
+CREATE INDEX idx_badges_event ON badges(event);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS badges;
+DROP INDEX IF EXISTS idx_badges_code;
+DROP INDEX IF EXISTS idx_badges_event;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_badges(
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_uuid UUID NOT NULL,
+    badge_uuid UUID NOT NULL,
+    awarded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_uuid) REFERENCES users(uuid),
+    FOREIGN KEY (badge_uuid) REFERENCES badges(uuid)
+);
+
+-- Create indexing
+CREATE UNIQUE INDEX idx_user_badges_user_uuid_badge_uuid ON user_badges(user_uuid, badge_uuid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_badges;
+DROP INDEX IF EXISTS idx_user_badges_user_uuid_badge_uuid;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_sessions(
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_uuid UUID NOT NULL,
+    tokens VARCHAR(64) NOT NULL,
+    device_name VARCHAR(100),
+    user_agent VARCHAR(255),
+    ip_address VARCHAR(45),
+    last_seen_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_uuid) REFERENCES users(uuid)
+);
+
+-- Create indexing
+CREATE UNIQUE INDEX idx_user_sessions_tokens ON user_sessions(tokens);
+CREATE INDEX idx_user_sessions_user_uuid ON user_sessions(user_uuid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_sessions;
+DROP INDEX IF EXISTS idx_user_sessions_tokens;
+DROP INDEX IF EXISTS idx_user_sessions_user_uuid;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+-- Akun yang sudah ada sebelum verifikasi email dianggap terverifikasi
+UPDATE users SET email_verified_at = created_at;
+
+CREATE TABLE email_verification_tokens(
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_uuid UUID NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_uuid) REFERENCES users(uuid)
+);
+
+-- Create indexing
+CREATE UNIQUE INDEX idx_email_verification_tokens_token_hash ON email_verification_tokens(token_hash);
+CREATE INDEX idx_email_verification_tokens_user_uuid ON email_verification_tokens(user_uuid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS email_verification_tokens;
+DROP INDEX IF EXISTS idx_email_verification_tokens_token_hash;
+DROP INDEX IF EXISTS idx_email_verification_tokens_user_uuid;
+
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE role_permissions(
+    role_uuid UUID NOT NULL,
+    permission_uuid UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (role_uuid, permission_uuid),
+    FOREIGN KEY (role_uuid) REFERENCES roles(uuid) ON DELETE CASCADE,
+    FOREIGN KEY (permission_uuid) REFERENCES permissions(uuid) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS role_permissions;
+-- +goose StatementEnd
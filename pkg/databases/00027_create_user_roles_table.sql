@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_roles(
+    user_uuid UUID NOT NULL,
+    role_uuid UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_uuid, role_uuid),
+    FOREIGN KEY (user_uuid) REFERENCES users(uuid),
+    FOREIGN KEY (role_uuid) REFERENCES roles(uuid) ON DELETE CASCADE
+);
+
+-- Create indexing
+CREATE INDEX idx_user_roles_role_uuid ON user_roles(role_uuid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_roles;
+DROP INDEX IF EXISTS idx_user_roles_role_uuid;
+-- +goose StatementEnd
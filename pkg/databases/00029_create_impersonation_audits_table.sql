@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE impersonation_audits(
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    admin_uuid UUID NOT NULL,
+    user_uuid UUID NOT NULL,
+    token_id VARCHAR(64) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (admin_uuid) REFERENCES users(uuid),
+    FOREIGN KEY (user_uuid) REFERENCES users(uuid)
+);
+
+-- Create indexing
+CREATE INDEX idx_impersonation_audits_admin_uuid ON impersonation_audits(admin_uuid);
+CREATE INDEX idx_impersonation_audits_user_uuid ON impersonation_audits(user_uuid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS impersonation_audits;
+DROP INDEX IF EXISTS idx_impersonation_audits_admin_uuid;
+DROP INDEX IF EXISTS idx_impersonation_audits_user_uuid;
+-- +goose StatementEnd
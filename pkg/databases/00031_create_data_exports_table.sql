@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE data_exports(
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_uuid UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    file_path VARCHAR(255),
+    error VARCHAR(255),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_uuid) REFERENCES users(uuid)
+);
+
+-- Create indexing
+CREATE INDEX idx_data_exports_user_uuid ON data_exports(user_uuid);
+CREATE INDEX idx_data_exports_expires_at ON data_exports(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS data_exports;
+DROP INDEX IF EXISTS idx_data_exports_user_uuid;
+DROP INDEX IF EXISTS idx_data_exports_expires_at;
+-- +goose StatementEnd
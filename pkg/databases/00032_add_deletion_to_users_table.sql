@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN deletion_scheduled_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE users ADD COLUMN purged_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+-- Dipakai job purge untuk mencari akun yang jatuh tempo
+CREATE INDEX idx_users_deletion_scheduled_at ON users(deletion_scheduled_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS purged_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
+-- +goose StatementEnd
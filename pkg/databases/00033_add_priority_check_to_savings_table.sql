@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Prioritas negatif sempat lolos tanpa validasi dan mengacaukan urutan alokasi
+UPDATE savings SET priority = 0 WHERE priority < 0;
+
+ALTER TABLE savings ADD CONSTRAINT chk_savings_priority CHECK (priority >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE savings DROP CONSTRAINT IF EXISTS chk_savings_priority;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- Seed data untuk badges
+-- event: saving_created, transaction_posted
+-- metric: savings_created, deposits_count, total_deposited, savings_completed, milestones_reached, deposit_streak_months
+INSERT INTO badges (code, name, description, icon, event, metric, threshold) VALUES
+('first_goal', 'First Goal', 'Create your first saving goal', 'flag', 'saving_created', 'savings_created', 1),
+('goal_collector', 'Goal Collector', 'Create 5 saving goals', 'layers', 'saving_created', 'savings_created', 5),
+('first_deposit', 'First Deposit', 'Make your first deposit', 'coin', 'transaction_posted', 'deposits_count', 1),
+('ten_deposits', 'Consistent Saver', 'Make 10 deposits', 'coins', 'transaction_posted', 'deposits_count', 10),
+('first_milestone', 'On Track', 'Reach your first milestone', 'star', 'transaction_posted', 'milestones_reached', 1),
+('first_goal_completed', 'Goal Achieved', 'Complete your first saving goal', 'trophy', 'transaction_posted', 'savings_completed', 1),
+('three_month_streak', '3-Month Streak', 'Deposit every month for 3 months in a row', 'fire', 'transaction_posted', 'deposit_streak_months', 3),
+('twelve_month_streak', '1-Year Streak', 'Deposit every month for 12 months in a row', 'crown', 'transaction_posted', 'deposit_streak_months', 12)
+ON CONFLICT (code) DO NOTHING;
@@ -0,0 +1,24 @@
+-- Seed data untuk roles dan permissions
+-- Nama permission harus sama dengan konstanta Permission* di pkg/services/rbac_service.go
+INSERT INTO permissions (name, description) VALUES
+('users:read', 'Search and view user accounts'),
+('users:write', 'Disable, enable and reset the password of user accounts'),
+('roles:manage', 'Assign and remove user roles'),
+('users:impersonate', 'Open a read-only session as another user'),
+('currencies:write', 'Create, update and delete currencies')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO roles (name, description) VALUES
+('admin', 'Full access to the admin API'),
+('support', 'Customer support, can view accounts and send password resets')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_uuid, permission_uuid)
+SELECT roles.uuid, permissions.uuid FROM roles, permissions
+WHERE roles.name = 'admin'
+   OR (roles.name = 'support' AND permissions.name IN ('users:read', 'users:write'))
+ON CONFLICT DO NOTHING;
+
+-- Admin pertama ditambahkan manual, contoh:
+-- INSERT INTO user_roles (user_uuid, role_uuid)
+-- SELECT users.uuid, roles.uuid FROM users, roles WHERE users.email = 'admin@example.com' AND roles.name = 'admin';
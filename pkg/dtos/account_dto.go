@@ -0,0 +1,20 @@
+package dtos
+
+import "time"
+
+type DataExportResponse struct {
+	UUID        string     `json:"uuid"`
+	Status      string     `json:"status"`
+	Error       *string    `json:"error,omitempty"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	CreatedAt   *time.Time `json:"created_at"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+type AccountDeletionResponse struct {
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
@@ -0,0 +1,89 @@
+package dtos
+
+import "time"
+
+type RoleResponse struct {
+	UUID        string   `json:"uuid"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+// AdminUserFilter mencari user berdasarkan nama, email atau nomor telepon
+type AdminUserFilter struct {
+	Query string `query:"q"`
+	Page  int    `query:"page"`
+	Limit int    `query:"limit"`
+}
+
+type AdminUserResponse struct {
+	UUID            string     `json:"uuid"`
+	Name            string     `json:"name"`
+	Email           string     `json:"email"`
+	PhoneNumber     *string    `json:"phone_number"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at"`
+	MfaEnabled      bool       `json:"mfa_enabled"`
+	DisabledAt      *time.Time `json:"disabled_at"`
+	CreatedAt       *time.Time `json:"created_at"`
+	Roles           []string   `json:"roles,omitempty"`
+}
+
+type CurrencyRequest struct {
+	CountryName    string `json:"country_name" validate:"required,max=255"`
+	CurrencyName   string `json:"currency_name" validate:"required,max=255"`
+	CountryFlag    string `json:"country_flag" validate:"required,max=10"`
+	CurrencySymbol string `json:"currency_symbol" validate:"required,max=10"`
+	CurrencyCode   string `json:"currency_code" validate:"required,len=3"`
+}
+
+type CurrencyResponse struct {
+	UUID           string     `json:"uuid"`
+	CountryName    string     `json:"country_name"`
+	CurrencyName   string     `json:"currency_name"`
+	CountryFlag    string     `json:"country_flag"`
+	CurrencySymbol string     `json:"currency_symbol"`
+	CurrencyCode   string     `json:"currency_code"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+type ImpersonateRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// ImpersonationResponse berisi token read-only untuk melihat aplikasi sebagai user lain
+type ImpersonationResponse struct {
+	UUID        string    `json:"uuid"`
+	UserUuid    string    `json:"user_uuid"`
+	TokenType   string    `json:"token_type"`
+	AccessToken string    `json:"access_token"`
+	ExpiresIn   int64     `json:"expires_in"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type ImpersonationFilter struct {
+	AdminUuid string `query:"admin_uuid"`
+	UserUuid  string `query:"user_uuid"`
+	Page      int    `query:"page"`
+	Limit     int    `query:"limit"`
+}
+
+type ImpersonationAuditResponse struct {
+	UUID      string     `json:"uuid"`
+	AdminUuid string     `json:"admin_uuid"`
+	UserUuid  string     `json:"user_uuid"`
+	Reason    string     `json:"reason"`
+	IPAddress *string    `json:"ip_address"`
+	UserAgent *string    `json:"user_agent"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	CreatedAt *time.Time `json:"created_at"`
+}
@@ -0,0 +1,27 @@
+package dtos
+
+import "time"
+
+type CreateApiKeyRequest struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1"`
+	// Kosong berarti key tidak pernah kedaluwarsa
+	ExpiresAt *time.Time `json:"expires_at"`
+	UserUUID  string     `json:"-"`
+}
+
+type ApiKeyResponse struct {
+	UUID       string     `json:"uuid"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
+
+// CreateApiKeyResponse contains the plain key, it is only shown once
+type CreateApiKeyResponse struct {
+	ApiKeyResponse
+	Key string `json:"key"`
+}
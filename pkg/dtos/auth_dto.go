@@ -1,16 +1,41 @@
 package dtos
 
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6,max=100"`
+	Email      string `json:"email" validate:"required,email"`
+	Password   string `json:"password" validate:"required,min=6,max=100"`
+	DeviceName string `json:"device_name" validate:"omitempty,max=100"`
+	UserAgent  string `json:"-"`
+	IPAddress  string `json:"-"`
 }
 
 type LoginResponse struct {
-	TokenType    string `json:"token_type"`
-	ExpiresIn    int64  `json:"expires_in"`
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	Email        string `json:"email"`
-	UserUuid     string `json:"user_uuid"`
-	Name         string `json:"name"`
+	TokenType     string `json:"token_type"`
+	ExpiresIn     int64  `json:"expires_in"`
+	AccessToken   string `json:"access_token"`
+	RefreshToken  string `json:"refresh_token"`
+	Email         string `json:"email"`
+	UserUuid      string `json:"user_uuid"`
+	Name          string `json:"name"`
+	SessionUuid   string `json:"session_uuid"`
+	EmailVerified bool   `json:"email_verified"`
+	MfaRequired   bool   `json:"mfa_required"`
+	MfaToken      string `json:"mfa_token,omitempty"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token                string `json:"token" validate:"required"`
+	Password             string `json:"password" validate:"required,max=100"`
+	ConfirmationPassword string `json:"confirmation_password" validate:"required,eqfield=Password"`
+}
+
+type VerifyOtpRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
 }
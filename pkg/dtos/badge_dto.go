@@ -0,0 +1,12 @@
+package dtos
+
+import "time"
+
+type UserBadgeResponse struct {
+	Code        string     `json:"code"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Icon        string     `json:"icon"`
+	Earned      bool       `json:"earned"`
+	AwardedAt   *time.Time `json:"awarded_at"`
+}
@@ -0,0 +1,30 @@
+package dtos
+
+import "time"
+
+type CategoryRequest struct {
+	Name     string `json:"name" validate:"required,min=1,max=50"`
+	Color    string `json:"color" validate:"required,hexcolor"`
+	Icon     string `json:"icon" validate:"required,max=50"`
+	UserUUID string `json:"-"`
+}
+
+type CategoryResponse struct {
+	UUID      string    `json:"uuid"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	Icon      string    `json:"icon"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CategoryTotalResponse berisi total tabungan untuk satu kategori
+type CategoryTotalResponse struct {
+	CategoryUUID   string  `json:"category_uuid,omitempty"` // kosong untuk tabungan tanpa kategori
+	Name           string  `json:"name"`
+	Color          string  `json:"color,omitempty"`
+	Icon           string  `json:"icon,omitempty"`
+	TotalSavings   int64   `json:"total_savings"`
+	TotalTarget    float64 `json:"total_target"`
+	TotalCollected float64 `json:"total_collected"`
+}
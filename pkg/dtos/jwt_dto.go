@@ -6,3 +6,30 @@ type GenerateTokenResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 }
+
+// JwkResponse is a public key in JSON Web Key format
+type JwkResponse struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JwksResponse struct {
+	Keys []JwkResponse `json:"keys"`
+}
+
+type WebviewTokenRequest struct {
+	// Full path of the page to open, such as /api/v1/savings/{uuid}/timeline
+	Path string `json:"path" validate:"required"`
+}
+
+type WebviewTokenResponse struct {
+	Token     string `json:"token"`
+	ExpiresIn int    `json:"expires_in"`
+	Path      string `json:"path"`
+}
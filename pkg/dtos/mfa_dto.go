@@ -0,0 +1,24 @@
+package dtos
+
+type MfaEnrollResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+	// Base64 encoded PNG of the otpauth URI
+	QrCode string `json:"qr_code"`
+}
+
+type MfaCodeRequest struct {
+	Code string `json:"code" validate:"required,max=32"`
+}
+
+type MfaRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type MfaVerifyRequest struct {
+	MfaToken   string `json:"mfa_token" validate:"required"`
+	Code       string `json:"code" validate:"required,max=32"`
+	DeviceName string `json:"device_name" validate:"omitempty,max=100"`
+	UserAgent  string `json:"-"`
+	IPAddress  string `json:"-"`
+}
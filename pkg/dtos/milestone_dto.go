@@ -0,0 +1,41 @@
+package dtos
+
+import "time"
+
+type MilestoneRequest struct {
+	Percentage float64 `json:"percentage" validate:"required,gt=0,lte=100"`
+	Label      string  `json:"label" validate:"max=100"`
+}
+
+type SavingMilestonesRequest struct {
+	Milestones []MilestoneRequest `json:"milestones" validate:"required,min=1,max=20,dive"`
+}
+
+// MilestoneResponse berisi definisi milestone beserta statusnya
+type MilestoneResponse struct {
+	Percentage float64    `json:"percentage"`
+	Label      string     `json:"label"`
+	Amount     float64    `json:"amount"`
+	Reached    bool       `json:"reached"`
+	ReachedAt  *time.Time `json:"reached_at"`
+}
+
+type MilestoneEventResponse struct {
+	UUID                  string    `json:"uuid"`
+	SavingUUID            string    `json:"saving_uuid"`
+	Percentage            float64   `json:"percentage"`
+	Label                 string    `json:"label"`
+	Amount                float64   `json:"amount"`
+	SavingTransactionUUID string    `json:"saving_transaction_uuid"`
+	ReachedAt             time.Time `json:"reached_at"`
+}
+
+type SavingTimelineResponse struct {
+	SavingUUID   string                   `json:"saving_uuid"`
+	Name         string                   `json:"name"`
+	TargetAmount float64                  `json:"target_amount"`
+	Collected    float64                  `json:"collected"`
+	Progress     float64                  `json:"progress"` // persen dari target
+	Milestones   []MilestoneResponse      `json:"milestones"`
+	Events       []MilestoneEventResponse `json:"events"`
+}
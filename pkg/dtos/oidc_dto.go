@@ -0,0 +1,18 @@
+package dtos
+
+type OidcAuthorizationResponse struct {
+	AuthorizationUrl string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+// OidcCallbackRequest is filled from the query string, or the form body for providers using form_post
+type OidcCallbackRequest struct {
+	Provider         string `json:"-" form:"-"`
+	Code             string `json:"code" form:"code" query:"code"`
+	State            string `json:"state" form:"state" query:"state"`
+	Error            string `json:"error" form:"error" query:"error"`
+	ErrorDescription string `json:"error_description" form:"error_description" query:"error_description"`
+	DeviceName       string `json:"device_name" form:"device_name" query:"device_name"`
+	UserAgent        string `json:"-" form:"-"`
+	IPAddress        string `json:"-" form:"-"`
+}
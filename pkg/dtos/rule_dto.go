@@ -0,0 +1,57 @@
+package dtos
+
+import "time"
+
+type SavingRuleRequest struct {
+	SavingUUID string   `json:"saving_uuid" validate:"required,uuid"`
+	Name       string   `json:"name" validate:"required,max=100"`
+	Type       string   `json:"type" validate:"required,oneof=round_up percentage"`
+	RoundTo    *float64 `json:"round_to" validate:"omitempty,gt=0"`           // wajib untuk round_up
+	Percentage *float64 `json:"percentage" validate:"omitempty,gt=0,lte=100"` // wajib untuk percentage
+	IsActive   *bool    `json:"is_active"`
+	UserUUID   string   `json:"-"`
+}
+
+type SavingRuleResponse struct {
+	UUID       string    `json:"uuid"`
+	SavingUUID string    `json:"saving_uuid"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	RoundTo    *float64  `json:"round_to"`
+	Percentage *float64  `json:"percentage"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// RuleExecutionResponse menghubungkan setoran yang dibuat rule dengan event sumbernya
+type RuleExecutionResponse struct {
+	UUID                  string    `json:"uuid"`
+	RuleUUID              string    `json:"rule_uuid"`
+	SavingUUID            string    `json:"saving_uuid"`
+	CashflowEventUUID     string    `json:"cashflow_event_uuid"`
+	SavingTransactionUUID string    `json:"saving_transaction_uuid"`
+	Amount                float64   `json:"amount"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+type CashflowRequest struct {
+	Type         string     `json:"type" validate:"required,oneof=income expense"`
+	Amount       float64    `json:"amount" validate:"required,gt=0"`
+	CurrencyCode string     `json:"currency_code" validate:"required,len=3"`
+	Description  string     `json:"description" validate:"max=255"`
+	ExternalID   string     `json:"external_id" validate:"max=100"`
+	OccurredAt   *time.Time `json:"occurred_at"`
+	UserUUID     string     `json:"-"`
+}
+
+type CashflowResponse struct {
+	UUID         string                  `json:"uuid"`
+	Type         string                  `json:"type"`
+	Amount       float64                 `json:"amount"`
+	CurrencyCode string                  `json:"currency_code"`
+	Description  string                  `json:"description,omitempty"`
+	ExternalID   string                  `json:"external_id,omitempty"`
+	OccurredAt   time.Time               `json:"occurred_at"`
+	Executions   []RuleExecutionResponse `json:"executions"`
+}
@@ -3,25 +3,51 @@ package dtos
 import "time"
 
 type SavingRequest struct {
-	Name           string  `form:"name" validate:"required,min=3,max=50"`
-	TargetAmount   float64 `form:"target_amount" validate:"required,gt=0"`
-	CurrencyCode   string  `form:"currency_code" validate:"required,len=3"`
-	FillingPlan    string  `form:"filling_plan" validate:"required,oneof=daily weekly monthly"`
-	FillingNominal float64 `form:"filling_nominal" validate:"required,gt=0"`
-	Image          string  `form:"image" validate:"required"`
-	UserUUID       string  `json:"user_uuid"`
+	Name           string   `form:"name" validate:"required,min=3,max=50"`
+	TargetAmount   float64  `form:"target_amount" validate:"required,gt=0"`
+	CurrencyCode   string   `form:"currency_code" validate:"required,len=3"`
+	FillingPlan    string   `form:"filling_plan" validate:"required,oneof=Daily Weekly Monthly"`
+	FillingNominal float64  `form:"filling_nominal" validate:"required,gt=0"`
+	Image          string   `form:"image" validate:"required"`
+	CategoryUUID   string   `form:"category_uuid" validate:"omitempty,uuid"`
+	Priority       int32    `form:"priority" validate:"gte=0"`
+	Deadline       string   `form:"deadline"` // format YYYY-MM-DD
+	Tags           []string `form:"tags" validate:"omitempty,max=10,dive,min=1,max=50"`
+	UserUUID       string   `json:"user_uuid"`
+}
+
+// SavingFilter berisi filter opsional untuk daftar tabungan
+type SavingFilter struct {
+	CategoryUUID string `query:"category_uuid" validate:"omitempty,uuid"`
+	Tag          string `query:"tag"`
+}
+
+type SavingTagsRequest struct {
+	Tags []string `json:"tags" validate:"max=10,dive,min=1,max=50"`
 }
 
 type SavingResponse struct {
-	UUID           string       `json:"uuid"`
-	User           UserResponse `json:"user"`
-	Name           string       `json:"name"`
-	TargetAmount   float64      `json:"target_amount"`
-	CurrencyCode   string       `json:"currency_code"`
-	CurrencyFlag   string       `json:"currency_flag"`
-	Image          string       `json:"image"`
-	FillingPlan    string       `json:"filling_plan"`
-	FillingNominal float64      `json:"filling_nominal"`
-	CreatedAt      time.Time    `json:"created_at"`
-	UpdatedAt      time.Time    `json:"updated_at"`
+	UUID           string            `json:"uuid"`
+	User           UserResponse      `json:"user"`
+	Name           string            `json:"name"`
+	TargetAmount   float64           `json:"target_amount"`
+	CurrencyCode   string            `json:"currency_code"`
+	CurrencyFlag   string            `json:"currency_flag"`
+	Image          string            `json:"image"`
+	FillingPlan    string            `json:"filling_plan"`
+	FillingNominal float64           `json:"filling_nominal"`
+	Priority       int32             `json:"priority"`
+	Deadline       *time.Time        `json:"deadline"`
+	Collected      float64           `json:"collected"`
+	Category       *CategoryResponse `json:"category"`
+	Tags           []string          `json:"tags"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+type SavingSummaryResponse struct {
+	TotalSavings   int64                   `json:"total_savings"`
+	TotalTarget    float64                 `json:"total_target"`
+	TotalCollected float64                 `json:"total_collected"`
+	Categories     []CategoryTotalResponse `json:"categories"`
 }
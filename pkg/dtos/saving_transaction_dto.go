@@ -0,0 +1,53 @@
+package dtos
+
+import "time"
+
+type SavingTransactionRequest struct {
+	Type       string  `json:"type" validate:"required,oneof=deposit withdrawal"`
+	Amount     float64 `json:"amount" validate:"required,gt=0"`
+	Note       string  `json:"note" validate:"max=255"`
+	SavingUUID string  `json:"-"`
+	UserUUID   string  `json:"-"`
+}
+
+type SavingTransactionResponse struct {
+	UUID          string                   `json:"uuid"`
+	SavingUUID    string                   `json:"saving_uuid"`
+	Type          string                   `json:"type"`
+	Amount        float64                  `json:"amount"`
+	Source        string                   `json:"source"`
+	ReferenceUUID string                   `json:"reference_uuid,omitempty"`
+	Note          string                   `json:"note,omitempty"`
+	Milestones    []MilestoneEventResponse `json:"milestones,omitempty"` // milestone yang tercapai oleh transaksi ini
+	CreatedAt     time.Time                `json:"created_at"`
+}
+
+type AllocationRequest struct {
+	Amount       float64 `json:"amount" validate:"required,gt=0"`
+	CurrencyCode string  `json:"currency_code" validate:"required,len=3"`
+	Strategy     string  `json:"strategy" validate:"required,oneof=priority proportional deadline"`
+	Preview      bool    `json:"preview"`
+	UserUUID     string  `json:"-"`
+}
+
+// AllocationItem berisi bagian alokasi untuk satu tabungan
+type AllocationItem struct {
+	SavingUUID      string     `json:"saving_uuid"`
+	Name            string     `json:"name"`
+	Priority        int32      `json:"priority"`
+	Deadline        *time.Time `json:"deadline"`
+	Remaining       float64    `json:"remaining"`
+	Amount          float64    `json:"amount"`
+	TransactionUUID string     `json:"transaction_uuid,omitempty"`
+	CreatedAt       time.Time  `json:"-"`
+}
+
+type AllocationResponse struct {
+	ReferenceUUID string           `json:"reference_uuid,omitempty"`
+	Strategy      string           `json:"strategy"`
+	Preview       bool             `json:"preview"`
+	Amount        float64          `json:"amount"`
+	Allocated     float64          `json:"allocated"`
+	Unallocated   float64          `json:"unallocated"`
+	Items         []AllocationItem `json:"items"`
+}
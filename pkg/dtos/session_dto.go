@@ -0,0 +1,13 @@
+package dtos
+
+import "time"
+
+type SessionResponse struct {
+	UUID       string    `json:"uuid"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	Current    bool      `json:"current"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
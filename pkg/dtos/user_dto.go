@@ -1,18 +1,44 @@
 package dtos
 
+import "time"
+
 type RegisterRequest struct {
 	Name                 string `form:"name" json:"name" validate:"required"`
 	Email                string `form:"email" json:"email" validate:"required,email"`
-	Password             string `form:"password" json:"password" validate:"required,min=6"`
+	Password             string `form:"password" json:"password" validate:"required,max=100"`
 	ConfirmationPassword string `form:"confirmation_password" json:"confirmation_password" validate:"required,eqfield=Password"`
 	PhoneNumber          string `form:"phone_number" json:"phone_number" validate:"required"`
 	Image                string `form:"photo_url" json:"photo_url" validate:"omitempty"`
 }
 
 type UserResponse struct {
-	UUID        string `json:"uuid"`
-	Name        string `json:"name"`
-	Email       string `json:"email"`
-	PhoneNumber string `json:"phone_number"`
-	Image       string `json:"image"`
+	UUID          string     `json:"uuid"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email"`
+	PhoneNumber   string     `json:"phone_number"`
+	Image         string     `json:"image"`
+	EmailVerified bool       `json:"email_verified"`
+	PhoneVerified bool       `json:"phone_verified"`
+	MfaEnabled    bool       `json:"mfa_enabled"`
+	CreatedAt     *time.Time `json:"created_at"`
+	// DeletionScheduledAt is set while a requested account deletion can still be cancelled
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+}
+
+// UpdateProfileRequest hanya mengubah field yang dikirim
+type UpdateProfileRequest struct {
+	Name        *string `form:"name" json:"name" validate:"omitempty,min=1,max=255"`
+	PhoneNumber *string `form:"phone_number" json:"phone_number" validate:"omitempty,min=6,max=20"`
+	Photo       *string `form:"-" json:"-"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword      string `json:"current_password" validate:"required"`
+	Password             string `json:"password" validate:"required,max=100"`
+	ConfirmationPassword string `json:"confirmation_password" validate:"required,eqfield=Password"`
+}
+
+type ChangeEmailRequest struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required"`
 }
@@ -1,13 +1,21 @@
 package helpers
 
 import (
+	"crypto/hmac"
 	cryptorand "crypto/rand"
+	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 
 	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"alfredo/tabunganku/config"
 )
 
 type ArgonParams struct {
@@ -16,16 +24,100 @@ type ArgonParams struct {
 	parallelism uint8
 	saltLength  uint32
 	keyLength   uint32
+	pepperId    string
 }
 
-func HashPassword(password string) (string, error) {
-	params := &ArgonParams{
-		memory:      19 * 1024,
-		iterations:  2,
-		parallelism: 1,
+// Default parameter mengikuti rekomendasi OWASP (m=19MiB, t=2, p=1)
+const (
+	defaultArgonMemory      = 19 * 1024
+	defaultArgonIterations  = 2
+	defaultArgonParallelism = 1
+)
+
+// currentArgonParams returns the parameters new hashes are created with
+func currentArgonParams() *ArgonParams {
+	return &ArgonParams{
+		memory:      uint32(configUint(config.ArgonMemory, defaultArgonMemory, 32)),
+		iterations:  uint32(configUint(config.ArgonIterations, defaultArgonIterations, 32)),
+		parallelism: uint8(configUint(config.ArgonParallelism, defaultArgonParallelism, 8)),
 		saltLength:  16,
 		keyLength:   32,
+		pepperId:    strings.ToLower(config.PasswordActivePepper),
 	}
+}
+
+func configUint(value string, fallback uint64, bitSize int) uint64 {
+	parsed, err := strconv.ParseUint(value, 10, bitSize)
+	if err != nil || parsed == 0 {
+		return fallback
+	}
+	return parsed
+}
+
+var (
+	peppersOnce sync.Once
+	peppers     map[string]string
+	peppersErr  error
+)
+
+// LoadPasswordPeppers loads the peppers from config once and checks that the active pepper is configured.
+// It is called at startup so a missing pepper stops the boot.
+func LoadPasswordPeppers() error {
+	peppersOnce.Do(func() {
+		peppers, peppersErr = config.GetPasswordPeppers()
+		if peppersErr != nil {
+			return
+		}
+		for id, secret := range peppers {
+			if secret == "" {
+				peppersErr = fmt.Errorf("password pepper %q is empty", id)
+				return
+			}
+		}
+		if active := strings.ToLower(config.PasswordActivePepper); active != "" && peppers[active] == "" {
+			peppersErr = fmt.Errorf("active password pepper %q is not configured", active)
+		}
+	})
+
+	return peppersErr
+}
+
+// CheckPepperIds fails when a stored hash uses a pepper that is not configured, those users could never login again
+func CheckPepperIds(ids []string) error {
+	if err := LoadPasswordPeppers(); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if peppers[id] == "" {
+			return fmt.Errorf("password hashes use pepper %q which is not configured", id)
+		}
+	}
+
+	return nil
+}
+
+// pepperPassword mixes the pepper with the given id into the password before hashing
+func pepperPassword(password string, pepperId string) ([]byte, error) {
+	if pepperId == "" {
+		return []byte(password), nil
+	}
+
+	if err := LoadPasswordPeppers(); err != nil {
+		return nil, err
+	}
+	secret, ok := peppers[pepperId]
+	if !ok {
+		return nil, fmt.Errorf("password pepper %q is not configured", pepperId)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(password))
+	return mac.Sum(nil), nil
+}
+
+func HashPassword(password string) (string, error) {
+	params := currentArgonParams()
 
 	// 1. Random salt
 	salt := make([]byte, params.saltLength)
@@ -34,56 +126,126 @@ func HashPassword(password string) (string, error) {
 	}
 
 	// 2. Hasilkan hash menggunakan Argon2id.
-	hash := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, params.keyLength)
+	peppered, err := pepperPassword(password, params.pepperId)
+	if err != nil {
+		return "", err
+	}
+	hash := argon2.IDKey(peppered, salt, params.iterations, params.memory, params.parallelism, params.keyLength)
 
 	// 3. Gabungkan semua informasi menjadi satu string untuk disimpan di database.
-	// Format: $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<hash>
+	// Format: $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>[,pepper=<id>]$<salt>$<hash>
 	b64Salt := base64.RawStdEncoding.EncodeToString(salt)
 	b64Hash := base64.RawStdEncoding.EncodeToString(hash)
 
-	encodedHash := fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
-		argon2.Version, params.memory, params.iterations, params.parallelism, b64Salt, b64Hash)
+	encodedParams := fmt.Sprintf("m=%d,t=%d,p=%d", params.memory, params.iterations, params.parallelism)
+	if params.pepperId != "" {
+		encodedParams += ",pepper=" + params.pepperId
+	}
+
+	encodedHash := fmt.Sprintf("$argon2id$v=%d$%s$%s$%s", argon2.Version, encodedParams, b64Salt, b64Hash)
 
 	return encodedHash, nil
 }
 
+// CheckPasswordHashWithArgon2 verifies a password against an Argon2id hash.
+// Legacy bcrypt hashes are accepted too, PasswordNeedsRehash reports them as outdated.
 func CheckPasswordHashWithArgon2(password, encodedHash string) (bool, error) {
+	if isBcryptHash(encodedHash) {
+		err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
 	// 1. Uraikan (parse) hash yang tersimpan untuk mendapatkan parameter, salt, dan hash asli.
+	params, salt, hash, err := decodeArgonHash(encodedHash)
+	if err != nil {
+		return false, err
+	}
+
+	// 2. Buat hash baru dari password yang diinput user menggunakan parameter dan pepper yang sama.
+	peppered, err := pepperPassword(password, params.pepperId)
+	if err != nil {
+		return false, err
+	}
+	comparisonHash := argon2.IDKey(peppered, salt, params.iterations, params.memory, params.parallelism, params.keyLength)
+
+	// 3. Bandingkan kedua hash dengan aman (constant-time comparison).
+	// Ini penting untuk mencegah timing attack.
+	if subtle.ConstantTimeCompare(hash, comparisonHash) == 1 {
+		return true, nil
+	}
+	return false, nil
+}
+
+// PasswordNeedsRehash reports whether a stored hash was created with another algorithm,
+// other Argon2 parameters or another pepper than the current config
+func PasswordNeedsRehash(encodedHash string) bool {
+	params, salt, hash, err := decodeArgonHash(encodedHash)
+	if err != nil {
+		return true
+	}
+
+	current := currentArgonParams()
+	return params.memory != current.memory ||
+		params.iterations != current.iterations ||
+		params.parallelism != current.parallelism ||
+		params.pepperId != current.pepperId ||
+		uint32(len(salt)) != current.saltLength ||
+		uint32(len(hash)) != current.keyLength
+}
+
+func isBcryptHash(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$2a$") ||
+		strings.HasPrefix(encodedHash, "$2b$") ||
+		strings.HasPrefix(encodedHash, "$2y$")
+}
+
+func decodeArgonHash(encodedHash string) (*ArgonParams, []byte, []byte, error) {
 	parts := strings.Split(encodedHash, "$")
-	if len(parts) != 6 {
-		return false, fmt.Errorf("format hash tidak valid")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, nil, nil, fmt.Errorf("format hash tidak valid")
 	}
 
 	var version int
-	params := &ArgonParams{}
 	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
 	if err != nil || version != argon2.Version {
-		return false, fmt.Errorf("versi argon2 tidak kompatibel")
+		return nil, nil, nil, fmt.Errorf("versi argon2 tidak kompatibel")
 	}
 
-	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism)
-	if err != nil {
-		return false, fmt.Errorf("gagal mem-parse parameter hash")
+	params := &ArgonParams{}
+	for _, param := range strings.Split(parts[3], ",") {
+		key, value, _ := strings.Cut(param, "=")
+		switch key {
+		case "m":
+			_, err = fmt.Sscanf(value, "%d", &params.memory)
+		case "t":
+			_, err = fmt.Sscanf(value, "%d", &params.iterations)
+		case "p":
+			_, err = fmt.Sscanf(value, "%d", &params.parallelism)
+		case "pepper":
+			params.pepperId = value
+		}
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("gagal mem-parse parameter hash")
+		}
+	}
+	if params.memory == 0 || params.iterations == 0 || params.parallelism == 0 {
+		return nil, nil, nil, fmt.Errorf("gagal mem-parse parameter hash")
 	}
 
 	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
 	if err != nil {
-		return false, fmt.Errorf("gagal decode salt")
+		return nil, nil, nil, fmt.Errorf("gagal decode salt")
 	}
 
 	hash, err := base64.RawStdEncoding.DecodeString(parts[5])
 	if err != nil {
-		return false, fmt.Errorf("gagal decode hash")
+		return nil, nil, nil, fmt.Errorf("gagal decode hash")
 	}
+	params.saltLength = uint32(len(salt))
 	params.keyLength = uint32(len(hash))
 
-	// 2. Buat hash baru dari password yang diinput user menggunakan parameter yang sama.
-	comparisonHash := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, params.keyLength)
-
-	// 3. Bandingkan kedua hash dengan aman (constant-time comparison).
-	// Ini penting untuk mencegah timing attack.
-	if subtle.ConstantTimeCompare(hash, comparisonHash) == 1 {
-		return true, nil
-	}
-	return false, nil
+	return params, salt, hash, nil
 }
@@ -0,0 +1,76 @@
+// Package token generates secrets for sessions, one-time links and codes with crypto/rand.
+// Since Go 1.24 crypto/rand.Read never fails, so the helpers do not return an error.
+package token
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// Ukuran entropy dalam bit untuk tiap jenis token
+const (
+	// SessionEntropy is used for the tokens claim and other long lived secrets
+	SessionEntropy = 256
+	// LinkEntropy is used for one-time tokens sent by email
+	LinkEntropy = 256
+	// IdEntropy is enough for identifiers that only have to be unique, like the JWT id
+	IdEntropy = 128
+)
+
+const Digits = "0123456789"
+
+// Bytes returns size random bytes
+func Bytes(size int) []byte {
+	b := make([]byte, size)
+	_, _ = rand.Read(b)
+	return b
+}
+
+// URLSafe returns a base64url token (without padding) with at least the given bits of entropy
+func URLSafe(bits int) string {
+	return base64.RawURLEncoding.EncodeToString(Bytes(byteSize(bits)))
+}
+
+// Hex returns a lowercase hex token with at least the given bits of entropy
+func Hex(bits int) string {
+	return hex.EncodeToString(Bytes(byteSize(bits)))
+}
+
+// Numeric returns a code of the given number of digits, leading zeros included
+func Numeric(digits int) string {
+	return FromAlphabet(Digits, digits)
+}
+
+// FromAlphabet returns length characters picked uniformly from alphabet, which has at most 256 characters
+func FromAlphabet(alphabet string, length int) string {
+	// Byte di atas batas ini dibuang agar tidak ada karakter yang lebih sering muncul (modulo bias)
+	limit := 256 - 256%len(alphabet)
+
+	result := make([]byte, 0, length)
+	buf := make([]byte, length)
+	for len(result) < length {
+		_, _ = rand.Read(buf)
+		for _, b := range buf {
+			if int(b) >= limit {
+				continue
+			}
+			result = append(result, alphabet[int(b)%len(alphabet)])
+			if len(result) == length {
+				break
+			}
+		}
+	}
+
+	return string(result)
+}
+
+// Equal compares two secrets in constant time
+func Equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+func byteSize(bits int) int {
+	return (bits + 7) / 8
+}
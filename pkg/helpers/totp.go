@@ -0,0 +1,79 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	cryptorand "crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"alfredo/tabunganku/pkg/helpers/token"
+)
+
+const (
+	totpPeriod = 30
+	totpDigits = 6
+	// Toleransi satu periode sebelum dan sesudah untuk jam device yang sedikit meleset
+	totpSkew = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTotpSecret returns a random base32 secret for RFC 6238 authenticator apps
+func GenerateTotpSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := cryptorand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// TotpURI builds the otpauth:// URI that authenticator apps scan
+func TotpURI(issuer string, account string, secret string) string {
+	label := url.PathEscape(issuer + ":" + account)
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(totpPeriod))
+
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, query.Encode())
+}
+
+// ValidateTotp checks the code against the secret around the given time.
+// It returns the matched time step so callers can reject a code that was already used.
+func ValidateTotp(secret string, code string, at time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := at.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if token.Equal(totpCode(key, step), code) {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+func totpCode(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
@@ -32,9 +32,37 @@ var authSet = wire.NewSet(
 	initDBPostgresSet,
 	services.NewUserService,
 	repositories.NewUserRepository,
+	repositories.NewSessionRepository,
+	services.NewMailerService,
+	services.NewEmailVerificationService,
+	repositories.NewEmailVerificationRepository,
+	services.NewMfaService,
+	repositories.NewMfaRepository,
+	services.NewLoginGuardService,
+	services.NewApiKeyService,
+	repositories.NewApiKeyRepository,
+	services.NewOidcService,
+	repositories.NewIdentityRepository,
+	services.NewPasswordPolicyService,
 	validator.NewValidator,
 )
 
+var badgeSet = wire.NewSet(
+	services.NewBadgeService,
+	repositories.NewBadgeRepository,
+)
+
+var rbacSet = wire.NewSet(
+	services.NewRbacService,
+	repositories.NewRoleRepository,
+)
+
+var ruleSet = wire.NewSet(
+	badgeSet,
+	services.NewRuleService,
+	repositories.NewRuleRepository,
+)
+
 func InitializeApplication() *config.Application {
 	wire.Build(config.NewApplication, config.InitDatabasePostgres)
 	return nil
@@ -44,6 +72,11 @@ func InitializeUserController() controllers.UserController {
 	wire.Build(
 		authSet,
 		jwtSet,
+		services.NewPasswordService,
+		services.NewOtpService,
+		services.NewSmsSenderService,
+		services.NewPhoneVerificationService,
+		repositories.NewPasswordResetRepository,
 		controllers.NewUserController,
 	)
 
@@ -54,10 +87,182 @@ func InitializeSavingController() controllers.SavingController {
 	wire.Build(
 		authSet,
 		services.NewJwtService,
+		badgeSet,
 		services.NewSavingService,
 		repositories.NewSavingRepository,
+		services.NewAllocationService,
+		repositories.NewSavingTransactionRepository,
+		services.NewSavingTransactionService,
+		services.NewMilestoneService,
+		repositories.NewMilestoneRepository,
 		controllers.NewSavingController,
 	)
 
 	return nil
 }
+
+func InitializeCategoryController() controllers.CategoryController {
+	wire.Build(
+		authSet,
+		jwtSet,
+		services.NewCategoryService,
+		repositories.NewCategoryRepository,
+		controllers.NewCategoryController,
+	)
+
+	return nil
+}
+
+func InitializeRuleController() controllers.RuleController {
+	wire.Build(
+		authSet,
+		jwtSet,
+		ruleSet,
+		controllers.NewRuleController,
+	)
+
+	return nil
+}
+
+func InitializeCashflowController() controllers.CashflowController {
+	wire.Build(
+		authSet,
+		jwtSet,
+		ruleSet,
+		controllers.NewCashflowController,
+	)
+
+	return nil
+}
+
+func InitializeBadgeController() controllers.BadgeController {
+	wire.Build(
+		authSet,
+		jwtSet,
+		badgeSet,
+		controllers.NewBadgeController,
+	)
+
+	return nil
+}
+
+func InitializeSessionController() controllers.SessionController {
+	wire.Build(
+		authSet,
+		jwtSet,
+		services.NewSessionService,
+		controllers.NewSessionController,
+	)
+
+	return nil
+}
+
+func InitializeMfaController() controllers.MfaController {
+	wire.Build(
+		authSet,
+		jwtSet,
+		controllers.NewMfaController,
+	)
+
+	return nil
+}
+
+func InitializeJwksController() controllers.JwksController {
+	wire.Build(
+		redisSet,
+		jwtSet,
+		controllers.NewJwksController,
+	)
+
+	return nil
+}
+
+func InitializeApiKeyController() controllers.ApiKeyController {
+	wire.Build(
+		authSet,
+		jwtSet,
+		controllers.NewApiKeyController,
+	)
+
+	return nil
+}
+
+func InitializeOidcController() controllers.OidcController {
+	wire.Build(
+		authSet,
+		jwtSet,
+		controllers.NewOidcController,
+	)
+
+	return nil
+}
+
+func InitializeProfileController() controllers.ProfileController {
+	wire.Build(
+		authSet,
+		jwtSet,
+		services.NewProfileService,
+		services.NewOtpService,
+		repositories.NewEmailChangeRepository,
+		controllers.NewProfileController,
+	)
+
+	return nil
+}
+
+var accountSet = wire.NewSet(
+	services.NewDataExportService,
+	repositories.NewDataExportRepository,
+	services.NewAccountDeletionService,
+	repositories.NewAccountDeletionRepository,
+)
+
+func InitializeAccountController() controllers.AccountController {
+	wire.Build(
+		authSet,
+		jwtSet,
+		accountSet,
+		controllers.NewAccountController,
+	)
+
+	return nil
+}
+
+func InitializeAccountJobs() services.AccountJobs {
+	wire.Build(
+		authSet,
+		jwtSet,
+		accountSet,
+		services.NewAccountJobs,
+	)
+
+	return nil
+}
+
+func InitializeAdminController() controllers.AdminController {
+	wire.Build(
+		authSet,
+		jwtSet,
+		rbacSet,
+		services.NewAdminService,
+		repositories.NewImpersonationRepository,
+		services.NewPasswordService,
+		repositories.NewPasswordResetRepository,
+		controllers.NewAdminController,
+	)
+
+	return nil
+}
+
+func InitializeCurrencyController() controllers.CurrencyController {
+	wire.Build(
+		authSet,
+		jwtSet,
+		rbacSet,
+		services.NewCurrencyService,
+		repositories.NewCurrencyRepository,
+		controllers.NewCurrencyController,
+	)
+
+	return nil
+}
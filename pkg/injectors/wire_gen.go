@@ -29,26 +29,423 @@ func InitializeUserController() controllers.UserController {
 	redisService := services.NewRedisService(redisRepository)
 	db := config.InitDatabasePostgres()
 	userRepository := repositories.NewUserRepository(db)
+	sessionRepository := repositories.NewSessionRepository(db)
 	jwtService := services.NewJwtService(redisService)
-	userService := services.NewUserService(userRepository, jwtService)
-	userController := controllers.NewUserController(redisService, userService)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
+	mailerService := services.NewMailerService()
+	emailVerificationService := services.NewEmailVerificationService(emailVerificationRepository, redisService, mailerService)
+	mfaRepository := repositories.NewMfaRepository(db)
+	customValidator := validator.NewValidator()
+	mfaService := services.NewMfaService(mfaRepository, redisService, customValidator)
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, customValidator)
+	oidcService := services.NewOidcService(redisService)
+	identityRepository := repositories.NewIdentityRepository(db)
+	passwordPolicyService := services.NewPasswordPolicyService()
+	userService := services.NewUserService(userRepository, sessionRepository, jwtService, emailVerificationService, mfaService, loginGuardService, apiKeyService, oidcService, identityRepository, passwordPolicyService)
+	passwordResetRepository := repositories.NewPasswordResetRepository(db)
+	passwordService := services.NewPasswordService(passwordResetRepository, userRepository, sessionRepository, jwtService, mailerService, redisService, passwordPolicyService, customValidator)
+	otpService := services.NewOtpService(redisService)
+	smsSenderService := services.NewSmsSenderService()
+	phoneVerificationService := services.NewPhoneVerificationService(userRepository, otpService, smsSenderService, customValidator)
+	userController := controllers.NewUserController(redisService, userService, passwordService, emailVerificationService, phoneVerificationService)
 	return userController
 }
 
 func InitializeSavingController() controllers.SavingController {
 	db := config.InitDatabasePostgres()
 	savingRepository := repositories.NewSavingRepository(db)
-	savingService := services.NewSavingService(savingRepository)
+	badgeRepository := repositories.NewBadgeRepository(db)
+	badgeService := services.NewBadgeService(badgeRepository)
+	customValidator := validator.NewValidator()
+	savingService := services.NewSavingService(savingRepository, badgeService, customValidator)
+	savingTransactionRepository := repositories.NewSavingTransactionRepository(db)
+	allocationService := services.NewAllocationService(savingTransactionRepository, badgeService, customValidator)
+	savingTransactionService := services.NewSavingTransactionService(savingTransactionRepository, badgeService, customValidator)
+	milestoneRepository := repositories.NewMilestoneRepository(db)
+	milestoneService := services.NewMilestoneService(milestoneRepository, customValidator)
 	client := config.InitRedis()
 	redisRepository := repositories.NewRedisRepository(client)
 	redisService := services.NewRedisService(redisRepository)
 	userRepository := repositories.NewUserRepository(db)
+	sessionRepository := repositories.NewSessionRepository(db)
 	jwtService := services.NewJwtService(redisService)
-	userService := services.NewUserService(userRepository, jwtService)
-	savingController := controllers.NewSavingController(savingService, redisService, userService)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
+	mailerService := services.NewMailerService()
+	emailVerificationService := services.NewEmailVerificationService(emailVerificationRepository, redisService, mailerService)
+	mfaRepository := repositories.NewMfaRepository(db)
+	mfaService := services.NewMfaService(mfaRepository, redisService, customValidator)
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, customValidator)
+	oidcService := services.NewOidcService(redisService)
+	identityRepository := repositories.NewIdentityRepository(db)
+	passwordPolicyService := services.NewPasswordPolicyService()
+	userService := services.NewUserService(userRepository, sessionRepository, jwtService, emailVerificationService, mfaService, loginGuardService, apiKeyService, oidcService, identityRepository, passwordPolicyService)
+	savingController := controllers.NewSavingController(savingService, allocationService, savingTransactionService, milestoneService, redisService, userService)
 	return savingController
 }
 
+func InitializeCategoryController() controllers.CategoryController {
+	db := config.InitDatabasePostgres()
+	categoryRepository := repositories.NewCategoryRepository(db)
+	customValidator := validator.NewValidator()
+	categoryService := services.NewCategoryService(categoryRepository, customValidator)
+	client := config.InitRedis()
+	redisRepository := repositories.NewRedisRepository(client)
+	redisService := services.NewRedisService(redisRepository)
+	userRepository := repositories.NewUserRepository(db)
+	sessionRepository := repositories.NewSessionRepository(db)
+	jwtService := services.NewJwtService(redisService)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
+	mailerService := services.NewMailerService()
+	emailVerificationService := services.NewEmailVerificationService(emailVerificationRepository, redisService, mailerService)
+	mfaRepository := repositories.NewMfaRepository(db)
+	mfaService := services.NewMfaService(mfaRepository, redisService, customValidator)
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, customValidator)
+	oidcService := services.NewOidcService(redisService)
+	identityRepository := repositories.NewIdentityRepository(db)
+	passwordPolicyService := services.NewPasswordPolicyService()
+	userService := services.NewUserService(userRepository, sessionRepository, jwtService, emailVerificationService, mfaService, loginGuardService, apiKeyService, oidcService, identityRepository, passwordPolicyService)
+	categoryController := controllers.NewCategoryController(categoryService, redisService, userService)
+	return categoryController
+}
+
+func InitializeRuleController() controllers.RuleController {
+	db := config.InitDatabasePostgres()
+	ruleRepository := repositories.NewRuleRepository(db)
+	badgeRepository := repositories.NewBadgeRepository(db)
+	badgeService := services.NewBadgeService(badgeRepository)
+	customValidator := validator.NewValidator()
+	ruleService := services.NewRuleService(ruleRepository, badgeService, customValidator)
+	client := config.InitRedis()
+	redisRepository := repositories.NewRedisRepository(client)
+	redisService := services.NewRedisService(redisRepository)
+	userRepository := repositories.NewUserRepository(db)
+	sessionRepository := repositories.NewSessionRepository(db)
+	jwtService := services.NewJwtService(redisService)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
+	mailerService := services.NewMailerService()
+	emailVerificationService := services.NewEmailVerificationService(emailVerificationRepository, redisService, mailerService)
+	mfaRepository := repositories.NewMfaRepository(db)
+	mfaService := services.NewMfaService(mfaRepository, redisService, customValidator)
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, customValidator)
+	oidcService := services.NewOidcService(redisService)
+	identityRepository := repositories.NewIdentityRepository(db)
+	passwordPolicyService := services.NewPasswordPolicyService()
+	userService := services.NewUserService(userRepository, sessionRepository, jwtService, emailVerificationService, mfaService, loginGuardService, apiKeyService, oidcService, identityRepository, passwordPolicyService)
+	ruleController := controllers.NewRuleController(ruleService, redisService, userService)
+	return ruleController
+}
+
+func InitializeCashflowController() controllers.CashflowController {
+	db := config.InitDatabasePostgres()
+	ruleRepository := repositories.NewRuleRepository(db)
+	badgeRepository := repositories.NewBadgeRepository(db)
+	badgeService := services.NewBadgeService(badgeRepository)
+	customValidator := validator.NewValidator()
+	ruleService := services.NewRuleService(ruleRepository, badgeService, customValidator)
+	client := config.InitRedis()
+	redisRepository := repositories.NewRedisRepository(client)
+	redisService := services.NewRedisService(redisRepository)
+	userRepository := repositories.NewUserRepository(db)
+	sessionRepository := repositories.NewSessionRepository(db)
+	jwtService := services.NewJwtService(redisService)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
+	mailerService := services.NewMailerService()
+	emailVerificationService := services.NewEmailVerificationService(emailVerificationRepository, redisService, mailerService)
+	mfaRepository := repositories.NewMfaRepository(db)
+	mfaService := services.NewMfaService(mfaRepository, redisService, customValidator)
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, customValidator)
+	oidcService := services.NewOidcService(redisService)
+	identityRepository := repositories.NewIdentityRepository(db)
+	passwordPolicyService := services.NewPasswordPolicyService()
+	userService := services.NewUserService(userRepository, sessionRepository, jwtService, emailVerificationService, mfaService, loginGuardService, apiKeyService, oidcService, identityRepository, passwordPolicyService)
+	cashflowController := controllers.NewCashflowController(ruleService, redisService, userService)
+	return cashflowController
+}
+
+func InitializeBadgeController() controllers.BadgeController {
+	db := config.InitDatabasePostgres()
+	badgeRepository := repositories.NewBadgeRepository(db)
+	badgeService := services.NewBadgeService(badgeRepository)
+	client := config.InitRedis()
+	redisRepository := repositories.NewRedisRepository(client)
+	redisService := services.NewRedisService(redisRepository)
+	userRepository := repositories.NewUserRepository(db)
+	sessionRepository := repositories.NewSessionRepository(db)
+	jwtService := services.NewJwtService(redisService)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
+	mailerService := services.NewMailerService()
+	emailVerificationService := services.NewEmailVerificationService(emailVerificationRepository, redisService, mailerService)
+	mfaRepository := repositories.NewMfaRepository(db)
+	customValidator := validator.NewValidator()
+	mfaService := services.NewMfaService(mfaRepository, redisService, customValidator)
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, customValidator)
+	oidcService := services.NewOidcService(redisService)
+	identityRepository := repositories.NewIdentityRepository(db)
+	passwordPolicyService := services.NewPasswordPolicyService()
+	userService := services.NewUserService(userRepository, sessionRepository, jwtService, emailVerificationService, mfaService, loginGuardService, apiKeyService, oidcService, identityRepository, passwordPolicyService)
+	badgeController := controllers.NewBadgeController(badgeService, redisService, userService)
+	return badgeController
+}
+
+func InitializeSessionController() controllers.SessionController {
+	db := config.InitDatabasePostgres()
+	sessionRepository := repositories.NewSessionRepository(db)
+	client := config.InitRedis()
+	redisRepository := repositories.NewRedisRepository(client)
+	redisService := services.NewRedisService(redisRepository)
+	jwtService := services.NewJwtService(redisService)
+	sessionService := services.NewSessionService(sessionRepository, jwtService, redisService)
+	userRepository := repositories.NewUserRepository(db)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
+	mailerService := services.NewMailerService()
+	emailVerificationService := services.NewEmailVerificationService(emailVerificationRepository, redisService, mailerService)
+	mfaRepository := repositories.NewMfaRepository(db)
+	customValidator := validator.NewValidator()
+	mfaService := services.NewMfaService(mfaRepository, redisService, customValidator)
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, customValidator)
+	oidcService := services.NewOidcService(redisService)
+	identityRepository := repositories.NewIdentityRepository(db)
+	passwordPolicyService := services.NewPasswordPolicyService()
+	userService := services.NewUserService(userRepository, sessionRepository, jwtService, emailVerificationService, mfaService, loginGuardService, apiKeyService, oidcService, identityRepository, passwordPolicyService)
+	sessionController := controllers.NewSessionController(sessionService, redisService, userService)
+	return sessionController
+}
+
+func InitializeMfaController() controllers.MfaController {
+	db := config.InitDatabasePostgres()
+	mfaRepository := repositories.NewMfaRepository(db)
+	client := config.InitRedis()
+	redisRepository := repositories.NewRedisRepository(client)
+	redisService := services.NewRedisService(redisRepository)
+	customValidator := validator.NewValidator()
+	mfaService := services.NewMfaService(mfaRepository, redisService, customValidator)
+	userRepository := repositories.NewUserRepository(db)
+	sessionRepository := repositories.NewSessionRepository(db)
+	jwtService := services.NewJwtService(redisService)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
+	mailerService := services.NewMailerService()
+	emailVerificationService := services.NewEmailVerificationService(emailVerificationRepository, redisService, mailerService)
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, customValidator)
+	oidcService := services.NewOidcService(redisService)
+	identityRepository := repositories.NewIdentityRepository(db)
+	passwordPolicyService := services.NewPasswordPolicyService()
+	userService := services.NewUserService(userRepository, sessionRepository, jwtService, emailVerificationService, mfaService, loginGuardService, apiKeyService, oidcService, identityRepository, passwordPolicyService)
+	mfaController := controllers.NewMfaController(mfaService, userService, redisService)
+	return mfaController
+}
+
+func InitializeJwksController() controllers.JwksController {
+	client := config.InitRedis()
+	redisRepository := repositories.NewRedisRepository(client)
+	redisService := services.NewRedisService(redisRepository)
+	jwtService := services.NewJwtService(redisService)
+	jwksController := controllers.NewJwksController(jwtService)
+	return jwksController
+}
+
+func InitializeApiKeyController() controllers.ApiKeyController {
+	db := config.InitDatabasePostgres()
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	customValidator := validator.NewValidator()
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, customValidator)
+	client := config.InitRedis()
+	redisRepository := repositories.NewRedisRepository(client)
+	redisService := services.NewRedisService(redisRepository)
+	userRepository := repositories.NewUserRepository(db)
+	sessionRepository := repositories.NewSessionRepository(db)
+	jwtService := services.NewJwtService(redisService)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
+	mailerService := services.NewMailerService()
+	emailVerificationService := services.NewEmailVerificationService(emailVerificationRepository, redisService, mailerService)
+	mfaRepository := repositories.NewMfaRepository(db)
+	mfaService := services.NewMfaService(mfaRepository, redisService, customValidator)
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	oidcService := services.NewOidcService(redisService)
+	identityRepository := repositories.NewIdentityRepository(db)
+	passwordPolicyService := services.NewPasswordPolicyService()
+	userService := services.NewUserService(userRepository, sessionRepository, jwtService, emailVerificationService, mfaService, loginGuardService, apiKeyService, oidcService, identityRepository, passwordPolicyService)
+	apiKeyController := controllers.NewApiKeyController(apiKeyService, redisService, userService)
+	return apiKeyController
+}
+
+func InitializeOidcController() controllers.OidcController {
+	client := config.InitRedis()
+	redisRepository := repositories.NewRedisRepository(client)
+	redisService := services.NewRedisService(redisRepository)
+	oidcService := services.NewOidcService(redisService)
+	db := config.InitDatabasePostgres()
+	userRepository := repositories.NewUserRepository(db)
+	sessionRepository := repositories.NewSessionRepository(db)
+	jwtService := services.NewJwtService(redisService)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
+	mailerService := services.NewMailerService()
+	emailVerificationService := services.NewEmailVerificationService(emailVerificationRepository, redisService, mailerService)
+	mfaRepository := repositories.NewMfaRepository(db)
+	customValidator := validator.NewValidator()
+	mfaService := services.NewMfaService(mfaRepository, redisService, customValidator)
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, customValidator)
+	identityRepository := repositories.NewIdentityRepository(db)
+	passwordPolicyService := services.NewPasswordPolicyService()
+	userService := services.NewUserService(userRepository, sessionRepository, jwtService, emailVerificationService, mfaService, loginGuardService, apiKeyService, oidcService, identityRepository, passwordPolicyService)
+	oidcController := controllers.NewOidcController(oidcService, userService)
+	return oidcController
+}
+
+func InitializeProfileController() controllers.ProfileController {
+	db := config.InitDatabasePostgres()
+	userRepository := repositories.NewUserRepository(db)
+	emailChangeRepository := repositories.NewEmailChangeRepository(db)
+	sessionRepository := repositories.NewSessionRepository(db)
+	client := config.InitRedis()
+	redisRepository := repositories.NewRedisRepository(client)
+	redisService := services.NewRedisService(redisRepository)
+	jwtService := services.NewJwtService(redisService)
+	mailerService := services.NewMailerService()
+	otpService := services.NewOtpService(redisService)
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	passwordPolicyService := services.NewPasswordPolicyService()
+	customValidator := validator.NewValidator()
+	profileService := services.NewProfileService(userRepository, emailChangeRepository, sessionRepository, jwtService, redisService, mailerService, otpService, loginGuardService, passwordPolicyService, customValidator)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
+	emailVerificationService := services.NewEmailVerificationService(emailVerificationRepository, redisService, mailerService)
+	mfaRepository := repositories.NewMfaRepository(db)
+	mfaService := services.NewMfaService(mfaRepository, redisService, customValidator)
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, customValidator)
+	oidcService := services.NewOidcService(redisService)
+	identityRepository := repositories.NewIdentityRepository(db)
+	userService := services.NewUserService(userRepository, sessionRepository, jwtService, emailVerificationService, mfaService, loginGuardService, apiKeyService, oidcService, identityRepository, passwordPolicyService)
+	profileController := controllers.NewProfileController(profileService, userService, redisService)
+	return profileController
+}
+
+func InitializeAccountController() controllers.AccountController {
+	db := config.InitDatabasePostgres()
+	dataExportRepository := repositories.NewDataExportRepository(db)
+	userRepository := repositories.NewUserRepository(db)
+	mailerService := services.NewMailerService()
+	dataExportService := services.NewDataExportService(dataExportRepository, userRepository, mailerService)
+	accountDeletionRepository := repositories.NewAccountDeletionRepository(db)
+	sessionRepository := repositories.NewSessionRepository(db)
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	client := config.InitRedis()
+	redisRepository := repositories.NewRedisRepository(client)
+	redisService := services.NewRedisService(redisRepository)
+	jwtService := services.NewJwtService(redisService)
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	customValidator := validator.NewValidator()
+	accountDeletionService := services.NewAccountDeletionService(accountDeletionRepository, sessionRepository, apiKeyRepository, jwtService, mailerService, loginGuardService, customValidator)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
+	emailVerificationService := services.NewEmailVerificationService(emailVerificationRepository, redisService, mailerService)
+	mfaRepository := repositories.NewMfaRepository(db)
+	mfaService := services.NewMfaService(mfaRepository, redisService, customValidator)
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, customValidator)
+	oidcService := services.NewOidcService(redisService)
+	identityRepository := repositories.NewIdentityRepository(db)
+	passwordPolicyService := services.NewPasswordPolicyService()
+	userService := services.NewUserService(userRepository, sessionRepository, jwtService, emailVerificationService, mfaService, loginGuardService, apiKeyService, oidcService, identityRepository, passwordPolicyService)
+	accountController := controllers.NewAccountController(dataExportService, accountDeletionService, userService, redisService)
+	return accountController
+}
+
+func InitializeAccountJobs() services.AccountJobs {
+	db := config.InitDatabasePostgres()
+	accountDeletionRepository := repositories.NewAccountDeletionRepository(db)
+	sessionRepository := repositories.NewSessionRepository(db)
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	client := config.InitRedis()
+	redisRepository := repositories.NewRedisRepository(client)
+	redisService := services.NewRedisService(redisRepository)
+	jwtService := services.NewJwtService(redisService)
+	mailerService := services.NewMailerService()
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	customValidator := validator.NewValidator()
+	accountDeletionService := services.NewAccountDeletionService(accountDeletionRepository, sessionRepository, apiKeyRepository, jwtService, mailerService, loginGuardService, customValidator)
+	dataExportRepository := repositories.NewDataExportRepository(db)
+	userRepository := repositories.NewUserRepository(db)
+	dataExportService := services.NewDataExportService(dataExportRepository, userRepository, mailerService)
+	accountJobs := services.NewAccountJobs(accountDeletionService, dataExportService)
+	return accountJobs
+}
+
+func InitializeAdminController() controllers.AdminController {
+	db := config.InitDatabasePostgres()
+	userRepository := repositories.NewUserRepository(db)
+	roleRepository := repositories.NewRoleRepository(db)
+	sessionRepository := repositories.NewSessionRepository(db)
+	client := config.InitRedis()
+	redisRepository := repositories.NewRedisRepository(client)
+	redisService := services.NewRedisService(redisRepository)
+	jwtService := services.NewJwtService(redisService)
+	passwordResetRepository := repositories.NewPasswordResetRepository(db)
+	mailerService := services.NewMailerService()
+	passwordPolicyService := services.NewPasswordPolicyService()
+	customValidator := validator.NewValidator()
+	passwordService := services.NewPasswordService(passwordResetRepository, userRepository, sessionRepository, jwtService, mailerService, redisService, passwordPolicyService, customValidator)
+	impersonationRepository := repositories.NewImpersonationRepository(db)
+	adminService := services.NewAdminService(userRepository, roleRepository, sessionRepository, jwtService, passwordService, impersonationRepository, customValidator)
+	rbacService := services.NewRbacService(roleRepository, userRepository, customValidator)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
+	emailVerificationService := services.NewEmailVerificationService(emailVerificationRepository, redisService, mailerService)
+	mfaRepository := repositories.NewMfaRepository(db)
+	mfaService := services.NewMfaService(mfaRepository, redisService, customValidator)
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, customValidator)
+	oidcService := services.NewOidcService(redisService)
+	identityRepository := repositories.NewIdentityRepository(db)
+	userService := services.NewUserService(userRepository, sessionRepository, jwtService, emailVerificationService, mfaService, loginGuardService, apiKeyService, oidcService, identityRepository, passwordPolicyService)
+	adminController := controllers.NewAdminController(adminService, rbacService, redisService, userService)
+	return adminController
+}
+
+func InitializeCurrencyController() controllers.CurrencyController {
+	db := config.InitDatabasePostgres()
+	currencyRepository := repositories.NewCurrencyRepository(db)
+	customValidator := validator.NewValidator()
+	currencyService := services.NewCurrencyService(currencyRepository, customValidator)
+	roleRepository := repositories.NewRoleRepository(db)
+	userRepository := repositories.NewUserRepository(db)
+	rbacService := services.NewRbacService(roleRepository, userRepository, customValidator)
+	client := config.InitRedis()
+	redisRepository := repositories.NewRedisRepository(client)
+	redisService := services.NewRedisService(redisRepository)
+	sessionRepository := repositories.NewSessionRepository(db)
+	jwtService := services.NewJwtService(redisService)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
+	mailerService := services.NewMailerService()
+	emailVerificationService := services.NewEmailVerificationService(emailVerificationRepository, redisService, mailerService)
+	mfaRepository := repositories.NewMfaRepository(db)
+	mfaService := services.NewMfaService(mfaRepository, redisService, customValidator)
+	loginGuardService := services.NewLoginGuardService(redisService, mailerService)
+	apiKeyRepository := repositories.NewApiKeyRepository(db)
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, customValidator)
+	oidcService := services.NewOidcService(redisService)
+	identityRepository := repositories.NewIdentityRepository(db)
+	passwordPolicyService := services.NewPasswordPolicyService()
+	userService := services.NewUserService(userRepository, sessionRepository, jwtService, emailVerificationService, mfaService, loginGuardService, apiKeyService, oidcService, identityRepository, passwordPolicyService)
+	currencyController := controllers.NewCurrencyController(currencyService, rbacService, redisService, userService)
+	return currencyController
+}
+
 // injector.go:
 
 var initDBPostgresSet = wire.NewSet(config.InitDatabasePostgres)
@@ -59,5 +456,15 @@ var jwtSet = wire.NewSet(services.NewJwtService)
 
 var authSet = wire.NewSet(
 	redisSet,
-	initDBPostgresSet, services.NewUserService, repositories.NewUserRepository, validator.NewValidator,
+	initDBPostgresSet, services.NewUserService, repositories.NewUserRepository, repositories.NewSessionRepository, services.NewMailerService, services.NewEmailVerificationService, repositories.NewEmailVerificationRepository, services.NewMfaService, repositories.NewMfaRepository, services.NewLoginGuardService, services.NewApiKeyService, repositories.NewApiKeyRepository, services.NewOidcService, repositories.NewIdentityRepository, services.NewPasswordPolicyService, validator.NewValidator,
 )
+
+var badgeSet = wire.NewSet(services.NewBadgeService, repositories.NewBadgeRepository)
+
+var rbacSet = wire.NewSet(services.NewRbacService, repositories.NewRoleRepository)
+
+var ruleSet = wire.NewSet(
+	badgeSet, services.NewRuleService, repositories.NewRuleRepository,
+)
+
+var accountSet = wire.NewSet(services.NewDataExportService, repositories.NewDataExportRepository, services.NewAccountDeletionService, repositories.NewAccountDeletionRepository)
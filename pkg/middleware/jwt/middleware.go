@@ -1,7 +1,9 @@
 package jwt
 
 import (
+	"errors"
 	"log"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
@@ -11,16 +13,30 @@ import (
 )
 
 type handle struct {
-	ctx         *fiber.Ctx
-	claim       jwt.MapClaims
-	jwtToken    string
-	userService services.UserService
-	Logger      log.Logger
+	ctx          *fiber.Ctx
+	claim        *services.TokenClaims
+	jwtToken     string
+	fromQuery    bool
+	jwtService   services.JwtService
+	userService  services.UserService
+	redisService services.RedisService
+	Logger       log.Logger
 }
 
 func JwtMiddleware(userService services.UserService, redisService services.RedisService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		jwtToken := c.Get("Authorization")
+		if key, ok := strings.CutPrefix(jwtToken, "ApiKey "); ok {
+			return handleApiKey(c, userService, key)
+		}
+
+		fromQuery := false
+		if jwtToken == "" && c.Query("token") != "" && services.IsWebviewPathAllowed(c.Path()) {
+			// Halaman webview dibuka dengan ?token=, hanya untuk route yang diizinkan
+			jwtToken = "Bearer " + c.Query("token")
+			fromQuery = true
+		}
+
 		if jwtToken == "" || jwtToken == "Bearer " || jwtToken == "Bearer" {
 			log.Println("Error while validating token", "error", "Unauthorized")
 			return c.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
@@ -44,54 +60,97 @@ func JwtMiddleware(userService services.UserService, redisService services.Redis
 			})
 		}
 
-		var token *jwt.Token
-
-		// Check the token using key from golang
-		token, err := jwtService.ValidateToken(jwtToken)
+		// Signature, iss, aud, exp, nbf and the custom claims are all checked here
+		claim, err := jwtService.ParseClaims(jwtToken)
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return c.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
+				Message: "Token Expired",
+				Code:    fiber.StatusUnauthorized,
+			})
+		}
 		if err != nil {
 			log.Println("Error while validating token", "error", err)
-		}
-
-		isExpired := jwtService.IsTokenExpired(jwtToken) //check if token is expired
-
-		if isExpired {
-			// If the token is expired, then revoke the token
 			return c.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
-				Message: "Token Expired",
+				Message: "Unauthorized",
 				Code:    fiber.StatusUnauthorized,
 			})
 		}
 
-		claim, ok := token.Claims.(jwt.MapClaims)
-
-		if !ok || !token.Valid {
+		// Token dari login yang sudah dicabut (logout, logout-all, refresh token dipakai ulang) tidak berlaku lagi
+		if jwtService.IsSessionRevoked(claim) {
+			log.Println("Error while validating token", "error", "Session Revoked")
 			return c.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
-				Message: "Unauthorized",
+				Message: "Token Not Valid",
 				Code:    fiber.StatusUnauthorized,
 			})
 		}
 
 		return handleToken(&handle{
-			ctx:         c,
-			claim:       claim,
-			userService: userService,
-			jwtToken:    jwtToken,
+			ctx:          c,
+			claim:        claim,
+			userService:  userService,
+			redisService: redisService,
+			jwtToken:     jwtToken,
+			fromQuery:    fromQuery,
+			jwtService:   jwtService,
 		})
 	}
 }
 
 func handleToken(data *handle) error {
 	// If it from golang then you must check the type access
-	switch data.claim["type"].(string) {
-	case "access":
-		break
-	case "refresh":
+	switch data.claim.Type {
+	case services.AccessToken:
+		// Access token asli tidak boleh muncul di URL
+		if data.fromQuery {
+			return data.ctx.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
+				Message: "Unauthorized",
+				Code:    fiber.StatusUnauthorized,
+			})
+		}
+	case services.RefreshToken:
 		return data.ctx.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
 			Message: "Unauthorized",
 			Code:    fiber.StatusUnauthorized,
 		})
-	case "webview":
-		break
+	case services.WebviewToken:
+		// Webview token hanya berlaku sekali, dari query parameter, untuk path yang tertulis di token
+		if !data.fromQuery || data.claim.Path != services.NormalizeWebviewPath(data.ctx.Path()) {
+			return data.ctx.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
+				Message: "Unauthorized",
+				Code:    fiber.StatusUnauthorized,
+			})
+		}
+		if err := data.jwtService.ConsumeWebviewToken(data.claim); err != nil {
+			return data.ctx.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
+				Message: "Token Not Valid",
+				Code:    fiber.StatusUnauthorized,
+			})
+		}
+	case services.ImpersonationToken:
+		if data.fromQuery || data.jwtService.IsImpersonationEnded(data.claim) {
+			return data.ctx.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
+				Message: "Token Not Valid",
+				Code:    fiber.StatusUnauthorized,
+			})
+		}
+		// Impersonation hanya untuk melihat, semua request yang mengubah data ditolak
+		if !services.IsImpersonationMethodAllowed(data.ctx.Method()) {
+			log.Println("Blocked write during impersonation", "admin", data.claim.ImpersonatorId, "user", data.claim.UserId, "path", data.ctx.Path())
+			return data.ctx.Status(fiber.StatusForbidden).JSON(dtos.ErrorResponseDTO{
+				Success: false,
+				Message: services.ErrImpersonationReadOnly.Error(),
+				Code:    fiber.StatusForbidden,
+			})
+		}
+		// Admin yang sudah dinonaktifkan tidak bisa melanjutkan impersonation
+		admin, err := data.userService.FindUserByUuid(data.claim.ImpersonatorId)
+		if err != nil || admin.DisabledAt != nil {
+			return data.ctx.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
+				Message: "Unauthorized",
+				Code:    fiber.StatusUnauthorized,
+			})
+		}
 	default:
 		return data.ctx.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
 			Message: "Unauthorized",
@@ -99,19 +158,78 @@ func handleToken(data *handle) error {
 		})
 	}
 
-	userId := data.claim["user_id"].(string)
-	userData, err := data.userService.FindUserByUuid(userId)
+	userData, err := data.userService.FindUserByUuid(data.claim.UserId)
 	if err != nil {
 		return data.ctx.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
 			Message: "Unauthorized",
 			Code:    fiber.StatusUnauthorized,
 		})
 	}
+	if userData.DisabledAt != nil {
+		return accountDisabledResponse(data.ctx)
+	}
 
 	data.ctx.Locals("user", userData)
 	data.ctx.Locals("email", userData.Email)
 	data.ctx.Locals("user_uuid", userData.UUID)
 	data.ctx.Locals("token", data.jwtToken)
+	data.ctx.Locals("claims", data.claim)
+
+	data.ctx.Locals("tokens", data.claim.Tokens)
+	services.TouchSession(data.redisService, data.claim.Tokens)
+
+	if data.claim.ImpersonatorId != "" {
+		data.ctx.Locals("impersonator_uuid", data.claim.ImpersonatorId)
+		data.ctx.Set("X-Impersonated-By", data.claim.ImpersonatorId)
+	}
 
 	return data.ctx.Next()
 }
+
+// handleApiKey authenticates a personal API key and fills the same locals as a token
+func handleApiKey(c *fiber.Ctx, userService services.UserService, key string) error {
+	claim, err := userService.AuthenticateApiKey(key)
+	if err != nil {
+		log.Println("Error while validating api key", "error", err)
+		return c.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
+			Message: "Unauthorized",
+			Code:    fiber.StatusUnauthorized,
+		})
+	}
+
+	userData, err := userService.FindUserByUuid(claim.UserId)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
+			Message: "Unauthorized",
+			Code:    fiber.StatusUnauthorized,
+		})
+	}
+	if userData.DisabledAt != nil {
+		return accountDisabledResponse(c)
+	}
+	// Key yang dibuat setelah hapus akun dijadwalkan juga tidak berlaku sampai penghapusan dibatalkan
+	if userData.DeletionScheduledAt != nil {
+		return c.Status(fiber.StatusForbidden).JSON(dtos.ErrorResponseDTO{
+			Success: false,
+			Message: "account is scheduled for deletion",
+			Code:    fiber.StatusForbidden,
+		})
+	}
+
+	c.Locals("user", userData)
+	c.Locals("email", userData.Email)
+	c.Locals("user_uuid", userData.UUID)
+	c.Locals("token", "")
+	c.Locals("claims", claim)
+	c.Locals("tokens", "")
+
+	return c.Next()
+}
+
+func accountDisabledResponse(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusForbidden).JSON(dtos.ErrorResponseDTO{
+		Success: false,
+		Message: services.ErrAccountDisabled.Error(),
+		Code:    fiber.StatusForbidden,
+	})
+}
@@ -0,0 +1,43 @@
+package jwt
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/services"
+)
+
+// RequirePermission blocks users whose roles do not grant every given permission.
+// It can be used on a single route or on a router group with Use, after JwtMiddleware.
+func RequirePermission(rbacService services.RbacService, permissions ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userUuid, ok := c.Locals("user_uuid").(string)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
+				Message: "Unauthorized",
+				Code:    fiber.StatusUnauthorized,
+			})
+		}
+
+		allowed, err := rbacService.HasPermissions(userUuid, permissions...)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(dtos.ErrorResponseDTO{
+				Success: false,
+				Message: "Failed to check permissions",
+				Code:    fiber.StatusInternalServerError,
+				Errors:  err.Error(),
+			})
+		}
+
+		if !allowed {
+			return c.Status(fiber.StatusForbidden).JSON(dtos.ErrorResponseDTO{
+				Success: false,
+				Message: "Forbidden",
+				Code:    fiber.StatusForbidden,
+				Errors:  permissions,
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,33 @@
+package jwt
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/services"
+)
+
+// RequireScope blocks tokens that were not granted every given scope.
+// It must run after JwtMiddleware because it reads the claims from the context.
+func RequireScope(scopes ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("claims").(*services.TokenClaims)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
+				Message: "Unauthorized",
+				Code:    fiber.StatusUnauthorized,
+			})
+		}
+
+		if !claims.HasScope(scopes...) {
+			return c.Status(fiber.StatusForbidden).JSON(dtos.ErrorResponseDTO{
+				Success: false,
+				Message: "Insufficient scope",
+				Code:    fiber.StatusForbidden,
+				Errors:  scopes,
+			})
+		}
+
+		return c.Next()
+	}
+}
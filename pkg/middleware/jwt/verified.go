@@ -0,0 +1,33 @@
+package jwt
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/services"
+)
+
+// RequireVerifiedEmail blocks accounts whose email is still unverified after the grace period.
+// It must run after JwtMiddleware because it reads the user from the context.
+func RequireVerifiedEmail() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, ok := c.Locals("user").(*models.User)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(dtos.ErrorResponseDTO{
+				Message: "Unauthorized",
+				Code:    fiber.StatusUnauthorized,
+			})
+		}
+
+		if services.IsEmailVerificationOverdue(user) {
+			return c.Status(fiber.StatusForbidden).JSON(dtos.ErrorResponseDTO{
+				Success: false,
+				Message: services.ErrEmailNotVerified.Error(),
+				Code:    fiber.StatusForbidden,
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -27,6 +27,9 @@ type Saving struct {
 	CreatedAt      *time.Time     `gorm:"column:created_at;type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt      *time.Time     `gorm:"column:updated_at;type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `gorm:"column:deleted_at;type:timestamp with time zone;index:idx_savings_deleted_at,priority:1" json:"deleted_at"`
+	CategoryUUID   *string        `gorm:"column:category_uuid;type:uuid;index:idx_savings_category_uuid,priority:1" json:"category_uuid"`
+	Priority       int32          `gorm:"column:priority;type:integer;not null" json:"priority"`
+	Deadline       *time.Time     `gorm:"column:deadline;type:date;index:idx_savings_deadline,priority:1" json:"deadline"`
 }
 
 // TableName Saving's table name
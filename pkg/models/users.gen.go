@@ -14,15 +14,22 @@ const TableNameUser = "users"
 
 // User mapped from table <users>
 type User struct {
-	UUID        string         `gorm:"column:uuid;type:uuid;primaryKey;default:gen_random_uuid()" json:"uuid"`
-	Name        string         `gorm:"column:name;type:character varying(255);not null;index:idx_users_name,priority:1" json:"name"`
-	Email       string         `gorm:"column:email;type:character varying(255);not null;uniqueIndex:idx_users_email,priority:1" json:"email"`
-	Password    string         `gorm:"column:password;type:character varying(255);not null" json:"password"`
-	PhoneNumber *string        `gorm:"column:phone_number;type:character varying(255);uniqueIndex:idx_users_phone_number,priority:1" json:"phone_number"`
-	Photo       *string        `gorm:"column:photo;type:character varying(255)" json:"photo"`
-	CreatedAt   *time.Time     `gorm:"column:created_at;type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt   *time.Time     `gorm:"column:updated_at;type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;type:timestamp with time zone;index:idx_users_deleted_at,priority:1" json:"deleted_at"`
+	UUID                string         `gorm:"column:uuid;type:uuid;primaryKey;default:gen_random_uuid()" json:"uuid"`
+	Name                string         `gorm:"column:name;type:character varying(255);not null;index:idx_users_name,priority:1" json:"name"`
+	Email               string         `gorm:"column:email;type:character varying(255);not null;uniqueIndex:idx_users_email,priority:1" json:"email"`
+	Password            string         `gorm:"column:password;type:character varying(255);not null" json:"password"`
+	PhoneNumber         *string        `gorm:"column:phone_number;type:character varying(255);uniqueIndex:idx_users_phone_number,priority:1" json:"phone_number"`
+	Photo               *string        `gorm:"column:photo;type:character varying(255)" json:"photo"`
+	CreatedAt           *time.Time     `gorm:"column:created_at;type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt           *time.Time     `gorm:"column:updated_at;type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `gorm:"column:deleted_at;type:timestamp with time zone;index:idx_users_deleted_at,priority:1" json:"deleted_at"`
+	EmailVerifiedAt     *time.Time     `gorm:"column:email_verified_at;type:timestamp with time zone" json:"email_verified_at"`
+	PhoneVerifiedAt     *time.Time     `gorm:"column:phone_verified_at;type:timestamp with time zone" json:"phone_verified_at"`
+	TotpSecret          *string        `gorm:"column:totp_secret;type:character varying(64)" json:"totp_secret"`
+	TotpEnabledAt       *time.Time     `gorm:"column:totp_enabled_at;type:timestamp with time zone" json:"totp_enabled_at"`
+	DisabledAt          *time.Time     `gorm:"column:disabled_at;type:timestamp with time zone" json:"disabled_at"`
+	DeletionScheduledAt *time.Time     `gorm:"column:deletion_scheduled_at;type:timestamp with time zone;index:idx_users_deletion_scheduled_at,priority:1" json:"deletion_scheduled_at"`
+	PurgedAt            *time.Time     `gorm:"column:purged_at;type:timestamp with time zone" json:"purged_at"`
 }
 
 // TableName User's table name
@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"alfredo/tabunganku/pkg/models"
+)
+
+// purgedTables hold personal data that is removed when an account is purged, in foreign key order
+var purgedTables = []string{
+	"milestone_events",
+	"saving_rules",
+	"cashflow_events",
+	"saving_transactions",
+	"savings",
+	"tags",
+	"categories",
+	"user_badges",
+	"user_sessions",
+	"password_reset_tokens",
+	"email_verification_tokens",
+	"email_change_tokens",
+	"user_recovery_codes",
+	"api_keys",
+	"user_identities",
+	"user_roles",
+	"data_exports",
+}
+
+type AccountDeletionRepository interface {
+	ScheduleDeletion(uuid string, deletionAt *time.Time) error
+	GetUsersToPurge(now time.Time, softDeletedBefore time.Time, limit int) ([]models.User, error)
+	GetUserFiles(uuid string) ([]string, error)
+	PurgeUser(uuid string) error
+}
+
+type accountDeletionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// ScheduleDeletion implements AccountDeletionRepository.
+// A nil deletionAt cancels the scheduled deletion.
+func (a *accountDeletionRepositoryImpl) ScheduleDeletion(uuid string, deletionAt *time.Time) error {
+	result := a.db.Model(&models.User{}).Where("uuid = ? AND purged_at IS NULL", uuid).
+		Updates(map[string]interface{}{"deletion_scheduled_at": deletionAt, "updated_at": time.Now()})
+	if result.Error != nil {
+		return fmt.Errorf("failed to schedule account deletion: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// GetUsersToPurge implements AccountDeletionRepository.
+// Besides scheduled deletions, rows that were soft deleted before softDeletedBefore are purged as well.
+func (a *accountDeletionRepositoryImpl) GetUsersToPurge(now time.Time, softDeletedBefore time.Time, limit int) ([]models.User, error) {
+	var users []models.User
+	if err := a.db.Unscoped().
+		Where("purged_at IS NULL").
+		Where("deletion_scheduled_at <= ? OR deleted_at <= ?", now, softDeletedBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return users, nil
+}
+
+// GetUserFiles implements AccountDeletionRepository.
+// It returns the uploaded photos and images and the data export zips of the user.
+func (a *accountDeletionRepositoryImpl) GetUserFiles(uuid string) ([]string, error) {
+	var files []string
+	if err := a.db.Unscoped().Model(&models.User{}).
+		Where("uuid = ? AND photo IS NOT NULL AND photo <> ''", uuid).
+		Pluck("photo", &files).Error; err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	var images []string
+	if err := a.db.Unscoped().Model(&models.Saving{}).
+		Where("user_uuid = ? AND image <> ''", uuid).
+		Pluck("image", &images).Error; err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	var exports []string
+	if err := a.db.Model(&models.DataExport{}).
+		Where("user_uuid = ? AND file_path IS NOT NULL", uuid).
+		Pluck("file_path", &exports).Error; err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	files = append(files, images...)
+	return append(files, exports...), nil
+}
+
+// PurgeUser implements AccountDeletionRepository.
+// Personal data is deleted and the user row is anonymized instead of deleted, so audit rows that
+// reference it stay valid. The original email is released and can be registered again.
+func (a *accountDeletionRepositoryImpl) PurgeUser(uuid string) error {
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		// Eksekusi rule mengacu ke rule, cashflow dan transaksi milik user
+		if err := tx.Exec(`DELETE FROM rule_executions
+			WHERE rule_uuid IN (SELECT uuid FROM saving_rules WHERE user_uuid = ?)
+			OR cashflow_event_uuid IN (SELECT uuid FROM cashflow_events WHERE user_uuid = ?)
+			OR saving_transaction_uuid IN (SELECT uuid FROM saving_transactions WHERE user_uuid = ?)`,
+			uuid, uuid, uuid).Error; err != nil {
+			return fmt.Errorf("failed to purge rule_executions: %w", err)
+		}
+
+		for _, table := range purgedTables {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE user_uuid = ?", uuid).Error; err != nil {
+				return fmt.Errorf("failed to purge %s: %w", table, err)
+			}
+		}
+
+		now := time.Now()
+		if err := tx.Exec(`UPDATE users SET
+			name = 'Deleted user',
+			email = ?,
+			password = '',
+			phone_number = NULL,
+			photo = NULL,
+			email_verified_at = NULL,
+			phone_verified_at = NULL,
+			totp_secret = NULL,
+			totp_enabled_at = NULL,
+			deletion_scheduled_at = NULL,
+			deleted_at = COALESCE(deleted_at, ?),
+			purged_at = ?,
+			updated_at = ?
+			WHERE uuid = ?`,
+			"deleted+"+uuid+"@deleted.invalid", now, now, now, uuid).Error; err != nil {
+			return fmt.Errorf("failed to anonymize user: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func NewAccountDeletionRepository(db *gorm.DB) AccountDeletionRepository {
+	return &accountDeletionRepositoryImpl{db: db}
+}
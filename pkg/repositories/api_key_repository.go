@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"alfredo/tabunganku/pkg/models"
+)
+
+var ErrApiKeyNotFound = errors.New("api key not found")
+
+type ApiKeyRepository interface {
+	CreateApiKey(apiKey *models.ApiKey) error
+	GetApiKeys(userUuid string) ([]models.ApiKey, error)
+	FindApiKeyByPrefix(prefix string) (*models.ApiKey, error)
+	RevokeApiKey(userUuid string, uuid string) error
+	RevokeAllApiKeys(userUuid string) error
+	TouchApiKey(uuid string, lastUsedAt time.Time) error
+}
+
+type apiKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// CreateApiKey implements ApiKeyRepository.
+func (a *apiKeyRepositoryImpl) CreateApiKey(apiKey *models.ApiKey) error {
+	if err := a.db.Create(apiKey).Error; err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
+// GetApiKeys implements ApiKeyRepository.
+func (a *apiKeyRepositoryImpl) GetApiKeys(userUuid string) ([]models.ApiKey, error) {
+	var apiKeys []models.ApiKey
+	if err := a.db.Where("user_uuid = ? AND revoked_at IS NULL", userUuid).Order("created_at DESC").Find(&apiKeys).Error; err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return apiKeys, nil
+}
+
+// FindApiKeyByPrefix implements ApiKeyRepository.
+func (a *apiKeyRepositoryImpl) FindApiKeyByPrefix(prefix string) (*models.ApiKey, error) {
+	var apiKey models.ApiKey
+	if err := a.db.Where("prefix = ? AND revoked_at IS NULL", prefix).First(&apiKey).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrApiKeyNotFound
+		}
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return &apiKey, nil
+}
+
+// RevokeApiKey implements ApiKeyRepository.
+func (a *apiKeyRepositoryImpl) RevokeApiKey(userUuid string, uuid string) error {
+	now := time.Now()
+	result := a.db.Model(&models.ApiKey{}).
+		Where("uuid = ? AND user_uuid = ? AND revoked_at IS NULL", uuid, userUuid).
+		Updates(map[string]interface{}{"revoked_at": now, "updated_at": now})
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke api key: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrApiKeyNotFound
+	}
+
+	return nil
+}
+
+// RevokeAllApiKeys implements ApiKeyRepository.
+func (a *apiKeyRepositoryImpl) RevokeAllApiKeys(userUuid string) error {
+	now := time.Now()
+	if err := a.db.Model(&models.ApiKey{}).
+		Where("user_uuid = ? AND revoked_at IS NULL", userUuid).
+		Updates(map[string]interface{}{"revoked_at": now, "updated_at": now}).Error; err != nil {
+		return fmt.Errorf("failed to revoke api keys: %w", err)
+	}
+
+	return nil
+}
+
+// TouchApiKey implements ApiKeyRepository.
+func (a *apiKeyRepositoryImpl) TouchApiKey(uuid string, lastUsedAt time.Time) error {
+	// Cukup ditulis sekali per menit supaya script yang sering memanggil API tidak membebani database
+	return a.db.Model(&models.ApiKey{}).
+		Where("uuid = ? AND (last_used_at IS NULL OR last_used_at < ?)", uuid, lastUsedAt.Add(-time.Minute)).
+		Update("last_used_at", lastUsedAt).Error
+}
+
+func NewApiKeyRepository(db *gorm.DB) ApiKeyRepository {
+	return &apiKeyRepositoryImpl{db: db}
+}
@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/models"
+)
+
+// Metrics that badge rules can compare against their threshold
+const (
+	BadgeMetricSavingsCreated      = "savings_created"
+	BadgeMetricDepositsCount       = "deposits_count"
+	BadgeMetricTotalDeposited      = "total_deposited"
+	BadgeMetricSavingsCompleted    = "savings_completed"
+	BadgeMetricMilestonesReached   = "milestones_reached"
+	BadgeMetricDepositStreakMonths = "deposit_streak_months"
+)
+
+var ErrUnknownBadgeMetric = errors.New("unknown badge metric")
+
+type BadgeRepository interface {
+	GetUserBadges(userUuid string) ([]*dtos.UserBadgeResponse, error)
+	FindPendingBadges(userUuid string, event string) ([]models.Badge, error)
+	Metric(userUuid string, metric string) (float64, error)
+	AwardBadge(userUuid string, badge *models.Badge) (*dtos.UserBadgeResponse, error)
+}
+
+type badgeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// GetUserBadges implements BadgeRepository.
+func (b *badgeRepositoryImpl) GetUserBadges(userUuid string) ([]*dtos.UserBadgeResponse, error) {
+	var rows []struct {
+		models.Badge
+		AwardedAt *time.Time
+	}
+
+	err := b.db.Table("badges").
+		Select("badges.*, user_badges.awarded_at").
+		Joins("LEFT JOIN user_badges ON user_badges.badge_uuid = badges.uuid AND user_badges.user_uuid = ?", userUuid).
+		Where("badges.is_active = TRUE OR user_badges.uuid IS NOT NULL").
+		Order("user_badges.awarded_at DESC NULLS LAST, badges.threshold ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dtos.UserBadgeResponse, 0, len(rows))
+	for _, row := range rows {
+		response = append(response, toUserBadgeResponse(&row.Badge, row.AwardedAt))
+	}
+
+	return response, nil
+}
+
+// FindPendingBadges implements BadgeRepository.
+func (b *badgeRepositoryImpl) FindPendingBadges(userUuid string, event string) ([]models.Badge, error) {
+	var badges []models.Badge
+	err := b.db.Where("event = ? AND is_active = TRUE", event).
+		Where("uuid NOT IN (?)", b.db.Model(&models.UserBadge{}).Select("badge_uuid").Where("user_uuid = ?", userUuid)).
+		Find(&badges).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return badges, nil
+}
+
+// Metric implements BadgeRepository.
+func (b *badgeRepositoryImpl) Metric(userUuid string, metric string) (float64, error) {
+	var value float64
+	var err error
+
+	switch metric {
+	case BadgeMetricSavingsCreated:
+		// Tabungan yang sudah dihapus tetap dihitung
+		err = b.db.Unscoped().Model(&models.Saving{}).Select("COUNT(*)").Where("user_uuid = ?", userUuid).Scan(&value).Error
+	case BadgeMetricDepositsCount:
+		err = b.db.Model(&models.SavingTransaction{}).Select("COUNT(*)").
+			Where("user_uuid = ? AND type = ?", userUuid, TransactionTypeDeposit).Scan(&value).Error
+	case BadgeMetricTotalDeposited:
+		err = b.db.Model(&models.SavingTransaction{}).Select("COALESCE(SUM(amount), 0)").
+			Where("user_uuid = ? AND type = ?", userUuid, TransactionTypeDeposit).Scan(&value).Error
+	case BadgeMetricSavingsCompleted:
+		err = b.db.Unscoped().Model(&models.Saving{}).Select("COUNT(*)").
+			Where("user_uuid = ? AND is_completed = TRUE", userUuid).Scan(&value).Error
+	case BadgeMetricMilestonesReached:
+		err = b.db.Model(&models.MilestoneEvent{}).Select("COUNT(*)").Where("user_uuid = ?", userUuid).Scan(&value).Error
+	case BadgeMetricDepositStreakMonths:
+		value, err = b.depositStreakMonths(userUuid)
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrUnknownBadgeMetric, metric)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// AwardBadge implements BadgeRepository.
+func (b *badgeRepositoryImpl) AwardBadge(userUuid string, badge *models.Badge) (*dtos.UserBadgeResponse, error) {
+	userBadge := models.UserBadge{UserUUID: userUuid, BadgeUUID: badge.UUID}
+	result := b.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&userBadge)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to award badge: %w", result.Error)
+	}
+
+	// Sudah diberikan oleh request lain
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return toUserBadgeResponse(badge, userBadge.AwardedAt), nil
+}
+
+// depositStreakMonths counts the consecutive months with at least one deposit,
+// ending in the current month or the month before it
+func (b *badgeRepositoryImpl) depositStreakMonths(userUuid string) (float64, error) {
+	var months []time.Time
+	err := b.db.Model(&models.SavingTransaction{}).
+		Select("DISTINCT DATE_TRUNC('month', created_at) AS month").
+		Where("user_uuid = ? AND type = ?", userUuid, TransactionTypeDeposit).
+		Order("month DESC").
+		Pluck("month", &months).Error
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	expected := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if len(months) > 0 && months[0].UTC().Before(expected) {
+		expected = expected.AddDate(0, -1, 0)
+	}
+
+	var streak float64
+	for _, month := range months {
+		month = month.UTC()
+		if month.Year() != expected.Year() || month.Month() != expected.Month() {
+			break
+		}
+		streak++
+		expected = expected.AddDate(0, -1, 0)
+	}
+
+	return streak, nil
+}
+
+func toUserBadgeResponse(badge *models.Badge, awardedAt *time.Time) *dtos.UserBadgeResponse {
+	return &dtos.UserBadgeResponse{
+		Code:        badge.Code,
+		Name:        badge.Name,
+		Description: badge.Description,
+		Icon:        badge.Icon,
+		Earned:      awardedAt != nil,
+		AwardedAt:   awardedAt,
+	}
+}
+
+func NewBadgeRepository(db *gorm.DB) BadgeRepository {
+	return &badgeRepositoryImpl{db: db}
+}
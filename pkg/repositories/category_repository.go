@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/models"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryExists   = errors.New("category already exists")
+)
+
+type CategoryRepository interface {
+	CreateCategory(req *dtos.CategoryRequest) (*dtos.CategoryResponse, error)
+	GetCategories(userUuid string) ([]*dtos.CategoryResponse, error)
+	FindCategory(userUuid string, uuid string) (*dtos.CategoryResponse, error)
+	UpdateCategory(uuid string, req *dtos.CategoryRequest) (*dtos.CategoryResponse, error)
+	DeleteCategory(userUuid string, uuid string) error
+}
+
+type categoryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// CreateCategory implements CategoryRepository.
+func (r *categoryRepositoryImpl) CreateCategory(req *dtos.CategoryRequest) (*dtos.CategoryResponse, error) {
+	if err := r.ensureUniqueName(req.UserUUID, req.Name, ""); err != nil {
+		return nil, err
+	}
+
+	category := models.Category{
+		UserUUID: req.UserUUID,
+		Name:     req.Name,
+		Color:    req.Color,
+		Icon:     req.Icon,
+	}
+
+	if err := r.db.Create(&category).Error; err != nil {
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return toCategoryResponse(&category), nil
+}
+
+// GetCategories implements CategoryRepository.
+func (r *categoryRepositoryImpl) GetCategories(userUuid string) ([]*dtos.CategoryResponse, error) {
+	var categories []models.Category
+	if err := r.db.Where("user_uuid = ?", userUuid).Order("name ASC").Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	response := make([]*dtos.CategoryResponse, 0, len(categories))
+	for i := range categories {
+		response = append(response, toCategoryResponse(&categories[i]))
+	}
+
+	return response, nil
+}
+
+// FindCategory implements CategoryRepository.
+func (r *categoryRepositoryImpl) FindCategory(userUuid string, uuid string) (*dtos.CategoryResponse, error) {
+	category, err := r.findCategory(userUuid, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCategoryResponse(category), nil
+}
+
+// findCategory returns the category model owned by the user
+func (r *categoryRepositoryImpl) findCategory(userUuid string, uuid string) (*models.Category, error) {
+	var category models.Category
+	if err := r.db.Where("uuid = ? AND user_uuid = ?", uuid, userUuid).First(&category).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return &category, nil
+}
+
+// UpdateCategory implements CategoryRepository.
+func (r *categoryRepositoryImpl) UpdateCategory(uuid string, req *dtos.CategoryRequest) (*dtos.CategoryResponse, error) {
+	category, err := r.findCategory(req.UserUUID, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.ensureUniqueName(req.UserUUID, req.Name, uuid); err != nil {
+		return nil, err
+	}
+
+	category.Name = req.Name
+	category.Color = req.Color
+	category.Icon = req.Icon
+
+	if err := r.db.Model(category).Select("name", "color", "icon", "updated_at").Updates(category).Error; err != nil {
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return toCategoryResponse(category), nil
+}
+
+// DeleteCategory implements CategoryRepository.
+func (r *categoryRepositoryImpl) DeleteCategory(userUuid string, uuid string) error {
+	category, err := r.findCategory(userUuid, uuid)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Lepaskan kategori dari tabungan yang memakainya
+		if err := tx.Model(&models.Saving{}).
+			Where("category_uuid = ?", category.UUID).
+			Update("category_uuid", nil).Error; err != nil {
+			return fmt.Errorf("failed to detach savings: %w", err)
+		}
+
+		if err := tx.Delete(category).Error; err != nil {
+			return fmt.Errorf("failed to delete category: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ensureUniqueName makes sure the user has no other category with the same name
+func (r *categoryRepositoryImpl) ensureUniqueName(userUuid string, name string, exceptUuid string) error {
+	query := r.db.Model(&models.Category{}).Where("user_uuid = ? AND name = ?", userUuid, name)
+	if exceptUuid != "" {
+		query = query.Where("uuid <> ?", exceptUuid)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return fmt.Errorf("please try again later")
+	}
+	if count > 0 {
+		return ErrCategoryExists
+	}
+
+	return nil
+}
+
+func toCategoryResponse(categoryModel *models.Category) *dtos.CategoryResponse {
+	response := &dtos.CategoryResponse{
+		UUID:  categoryModel.UUID,
+		Name:  categoryModel.Name,
+		Color: categoryModel.Color,
+		Icon:  categoryModel.Icon,
+	}
+	if categoryModel.CreatedAt != nil {
+		response.CreatedAt = *categoryModel.CreatedAt
+	}
+	if categoryModel.UpdatedAt != nil {
+		response.UpdatedAt = *categoryModel.UpdatedAt
+	}
+
+	return response
+}
+
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &categoryRepositoryImpl{db: db}
+}
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/models"
+)
+
+var (
+	ErrCurrencyNotFound = errors.New("currency not found")
+	ErrCurrencyExists   = errors.New("currency code already exists")
+	ErrCurrencyInUse    = errors.New("currency is still used by savings")
+)
+
+type CurrencyRepository interface {
+	GetCurrencies() ([]dtos.CurrencyResponse, error)
+	CreateCurrency(req *dtos.CurrencyRequest) (*dtos.CurrencyResponse, error)
+	UpdateCurrency(uuid string, req *dtos.CurrencyRequest) (*dtos.CurrencyResponse, error)
+	DeleteCurrency(uuid string) error
+}
+
+type currencyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// GetCurrencies implements CurrencyRepository.
+func (c *currencyRepositoryImpl) GetCurrencies() ([]dtos.CurrencyResponse, error) {
+	var currencies []models.Currency
+	if err := c.db.Order("currency_code ASC").Find(&currencies).Error; err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	responses := make([]dtos.CurrencyResponse, 0, len(currencies))
+	for i := range currencies {
+		responses = append(responses, *toCurrencyResponse(&currencies[i]))
+	}
+
+	return responses, nil
+}
+
+// CreateCurrency implements CurrencyRepository.
+func (c *currencyRepositoryImpl) CreateCurrency(req *dtos.CurrencyRequest) (*dtos.CurrencyResponse, error) {
+	code := strings.ToUpper(req.CurrencyCode)
+	if err := c.ensureCodeAvailable(code, ""); err != nil {
+		return nil, err
+	}
+
+	currency := models.Currency{
+		CountryName:    req.CountryName,
+		CurrencyName:   req.CurrencyName,
+		CountryFlag:    req.CountryFlag,
+		CurrencySymbol: req.CurrencySymbol,
+		CurrencyCode:   code,
+	}
+	if err := c.db.Create(&currency).Error; err != nil {
+		return nil, fmt.Errorf("failed to create currency: %w", err)
+	}
+
+	return toCurrencyResponse(&currency), nil
+}
+
+// UpdateCurrency implements CurrencyRepository.
+func (c *currencyRepositoryImpl) UpdateCurrency(uuid string, req *dtos.CurrencyRequest) (*dtos.CurrencyResponse, error) {
+	currency, err := c.findCurrency(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	code := strings.ToUpper(req.CurrencyCode)
+	if code != currency.CurrencyCode {
+		// Kode dipakai sebagai referensi oleh tabungan, jadi tidak boleh diganti selama masih dipakai
+		if err := c.ensureNotUsed(currency.CurrencyCode); err != nil {
+			return nil, err
+		}
+		if err := c.ensureCodeAvailable(code, currency.UUID); err != nil {
+			return nil, err
+		}
+	}
+
+	currency.CountryName = req.CountryName
+	currency.CurrencyName = req.CurrencyName
+	currency.CountryFlag = req.CountryFlag
+	currency.CurrencySymbol = req.CurrencySymbol
+	currency.CurrencyCode = code
+	if err := c.db.Save(currency).Error; err != nil {
+		return nil, fmt.Errorf("failed to update currency: %w", err)
+	}
+
+	return toCurrencyResponse(currency), nil
+}
+
+// DeleteCurrency implements CurrencyRepository.
+func (c *currencyRepositoryImpl) DeleteCurrency(uuid string) error {
+	currency, err := c.findCurrency(uuid)
+	if err != nil {
+		return err
+	}
+
+	if err := c.ensureNotUsed(currency.CurrencyCode); err != nil {
+		return err
+	}
+
+	if err := c.db.Delete(currency).Error; err != nil {
+		return fmt.Errorf("failed to delete currency: %w", err)
+	}
+
+	return nil
+}
+
+func (c *currencyRepositoryImpl) findCurrency(uuid string) (*models.Currency, error) {
+	var currency models.Currency
+	if err := c.db.Where("uuid = ?", uuid).First(&currency).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCurrencyNotFound
+		}
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return &currency, nil
+}
+
+// ensureCodeAvailable also checks soft deleted rows because the unique index covers them
+func (c *currencyRepositoryImpl) ensureCodeAvailable(code string, exceptUuid string) error {
+	var count int64
+	query := c.db.Unscoped().Model(&models.Currency{}).Where("currency_code = ?", code)
+	if exceptUuid != "" {
+		query = query.Where("uuid <> ?", exceptUuid)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return fmt.Errorf("please try again later")
+	}
+	if count > 0 {
+		return ErrCurrencyExists
+	}
+
+	return nil
+}
+
+func (c *currencyRepositoryImpl) ensureNotUsed(code string) error {
+	var count int64
+	if err := c.db.Model(&models.Saving{}).Where("currency_code = ?", code).Count(&count).Error; err != nil {
+		return fmt.Errorf("please try again later")
+	}
+	if count > 0 {
+		return ErrCurrencyInUse
+	}
+
+	return nil
+}
+
+func toCurrencyResponse(currency *models.Currency) *dtos.CurrencyResponse {
+	return &dtos.CurrencyResponse{
+		UUID:           currency.UUID,
+		CountryName:    currency.CountryName,
+		CurrencyName:   currency.CurrencyName,
+		CountryFlag:    currency.CountryFlag,
+		CurrencySymbol: currency.CurrencySymbol,
+		CurrencyCode:   currency.CurrencyCode,
+		CreatedAt:      currency.CreatedAt,
+		UpdatedAt:      currency.UpdatedAt,
+	}
+}
+
+func NewCurrencyRepository(db *gorm.DB) CurrencyRepository {
+	return &currencyRepositoryImpl{db: db}
+}
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"alfredo/tabunganku/pkg/models"
+)
+
+// Status of a data export
+const (
+	DataExportPending    = "pending"
+	DataExportProcessing = "processing"
+	DataExportCompleted  = "completed"
+	DataExportFailed     = "failed"
+)
+
+var (
+	ErrDataExportNotFound   = errors.New("data export not found")
+	ErrDataExportInProgress = errors.New("a data export is already in progress")
+)
+
+type DataExportRepository interface {
+	CreateExport(userUuid string) (*models.DataExport, error)
+	FindExport(userUuid string, uuid string) (*models.DataExport, error)
+	MarkExportProcessing(uuid string) error
+	CompleteExport(uuid string, filePath string, expiresAt time.Time) error
+	FailExport(uuid string, reason string) error
+	GetExpiredExports(now time.Time) ([]models.DataExport, error)
+	FailStaleExports(startedBefore time.Time) error
+	DeleteExport(uuid string) error
+	GetUserSavings(userUuid string) ([]models.Saving, error)
+	GetUserTransactions(userUuid string) ([]models.SavingTransaction, error)
+}
+
+type dataExportRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// CreateExport implements DataExportRepository.
+// Satu user hanya boleh punya satu export yang sedang berjalan.
+func (d *dataExportRepositoryImpl) CreateExport(userUuid string) (*models.DataExport, error) {
+	export := models.DataExport{UserUUID: userUuid, Status: DataExportPending}
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.DataExport{}).
+			Where("user_uuid = ? AND status IN ?", userUuid, []string{DataExportPending, DataExportProcessing}).
+			Count(&count).Error; err != nil {
+			return fmt.Errorf("please try again later")
+		}
+		if count > 0 {
+			return ErrDataExportInProgress
+		}
+
+		if err := tx.Create(&export).Error; err != nil {
+			return fmt.Errorf("failed to create data export: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+// FindExport implements DataExportRepository.
+func (d *dataExportRepositoryImpl) FindExport(userUuid string, uuid string) (*models.DataExport, error) {
+	var export models.DataExport
+	if err := d.db.Where("uuid = ? AND user_uuid = ?", uuid, userUuid).First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDataExportNotFound
+		}
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return &export, nil
+}
+
+// MarkExportProcessing implements DataExportRepository.
+func (d *dataExportRepositoryImpl) MarkExportProcessing(uuid string) error {
+	return d.updateExport(uuid, map[string]interface{}{"status": DataExportProcessing})
+}
+
+// CompleteExport implements DataExportRepository.
+func (d *dataExportRepositoryImpl) CompleteExport(uuid string, filePath string, expiresAt time.Time) error {
+	return d.updateExport(uuid, map[string]interface{}{
+		"status":       DataExportCompleted,
+		"file_path":    filePath,
+		"completed_at": time.Now(),
+		"expires_at":   expiresAt,
+	})
+}
+
+// FailExport implements DataExportRepository.
+func (d *dataExportRepositoryImpl) FailExport(uuid string, reason string) error {
+	if len(reason) > 255 {
+		reason = reason[:255]
+	}
+
+	return d.updateExport(uuid, map[string]interface{}{"status": DataExportFailed, "error": reason})
+}
+
+// GetExpiredExports implements DataExportRepository.
+func (d *dataExportRepositoryImpl) GetExpiredExports(now time.Time) ([]models.DataExport, error) {
+	var exports []models.DataExport
+	if err := d.db.Where("expires_at IS NOT NULL AND expires_at <= ?", now).Find(&exports).Error; err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return exports, nil
+}
+
+// FailStaleExports implements DataExportRepository.
+// Export yang berhenti di tengah jalan (misalnya server restart) ditandai gagal supaya user bisa meminta lagi.
+func (d *dataExportRepositoryImpl) FailStaleExports(startedBefore time.Time) error {
+	if err := d.db.Model(&models.DataExport{}).
+		Where("status IN ? AND created_at < ?", []string{DataExportPending, DataExportProcessing}, startedBefore).
+		Updates(map[string]interface{}{"status": DataExportFailed, "error": "export was interrupted", "updated_at": time.Now()}).Error; err != nil {
+		return fmt.Errorf("failed to update data export: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExport implements DataExportRepository.
+func (d *dataExportRepositoryImpl) DeleteExport(uuid string) error {
+	if err := d.db.Where("uuid = ?", uuid).Delete(&models.DataExport{}).Error; err != nil {
+		return fmt.Errorf("failed to delete data export: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserSavings implements DataExportRepository.
+// Deleted savings are still stored, so they are part of the export too.
+func (d *dataExportRepositoryImpl) GetUserSavings(userUuid string) ([]models.Saving, error) {
+	var savings []models.Saving
+	if err := d.db.Unscoped().Where("user_uuid = ?", userUuid).Order("created_at ASC").Find(&savings).Error; err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return savings, nil
+}
+
+// GetUserTransactions implements DataExportRepository.
+func (d *dataExportRepositoryImpl) GetUserTransactions(userUuid string) ([]models.SavingTransaction, error) {
+	var transactions []models.SavingTransaction
+	if err := d.db.Unscoped().Where("user_uuid = ?", userUuid).Order("created_at ASC").Find(&transactions).Error; err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return transactions, nil
+}
+
+func (d *dataExportRepositoryImpl) updateExport(uuid string, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now()
+	if err := d.db.Model(&models.DataExport{}).Where("uuid = ?", uuid).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update data export: %w", err)
+	}
+
+	return nil
+}
+
+func NewDataExportRepository(db *gorm.DB) DataExportRepository {
+	return &dataExportRepositoryImpl{db: db}
+}
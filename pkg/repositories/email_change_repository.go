@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"alfredo/tabunganku/pkg/models"
+)
+
+var ErrEmailChangeTokenInvalid = errors.New("email change token is invalid or has expired")
+
+type EmailChangeRepository interface {
+	CreateEmailChangeToken(userUuid string, newEmail string, tokenHash string, expiresAt time.Time) error
+	ConsumeEmailChangeToken(tokenHash string) (userUuid string, err error)
+}
+
+type emailChangeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// CreateEmailChangeToken implements EmailChangeRepository.
+// Permintaan sebelumnya yang belum dikonfirmasi langsung tidak berlaku.
+func (e *emailChangeRepositoryImpl) CreateEmailChangeToken(userUuid string, newEmail string, tokenHash string, expiresAt time.Time) error {
+	return e.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.EmailChangeToken{}).
+			Where("user_uuid = ? AND used_at IS NULL", userUuid).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		token := models.EmailChangeToken{
+			UserUUID:  userUuid,
+			NewEmail:  newEmail,
+			TokenHash: tokenHash,
+			ExpiresAt: expiresAt,
+		}
+		if err := tx.Create(&token).Error; err != nil {
+			return fmt.Errorf("failed to create email change token: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ConsumeEmailChangeToken implements EmailChangeRepository.
+// The new address is confirmed by the token itself, so it is marked as verified right away.
+func (e *emailChangeRepositoryImpl) ConsumeEmailChangeToken(tokenHash string) (userUuid string, err error) {
+	err = e.db.Transaction(func(tx *gorm.DB) error {
+		var token models.EmailChangeToken
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+			First(&token).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrEmailChangeTokenInvalid
+			}
+			return err
+		}
+
+		// Email bisa saja sudah dipakai akun lain sejak permintaan dibuat
+		var count int64
+		if err := tx.Unscoped().Model(&models.User{}).
+			Where("email = ? AND uuid <> ?", token.NewEmail, token.UserUUID).
+			Count(&count).Error; err != nil {
+			return fmt.Errorf("please try again later")
+		}
+		if count > 0 {
+			return ErrEmailTaken
+		}
+
+		now := time.Now()
+		if err := tx.Model(&token).Update("used_at", now).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.User{}).
+			Where("uuid = ?", token.UserUUID).
+			Updates(map[string]interface{}{"email": token.NewEmail, "email_verified_at": now, "updated_at": now}).Error; err != nil {
+			return fmt.Errorf("failed to change email: %w", err)
+		}
+
+		userUuid = token.UserUUID
+		return nil
+	})
+
+	return userUuid, err
+}
+
+func NewEmailChangeRepository(db *gorm.DB) EmailChangeRepository {
+	return &emailChangeRepositoryImpl{db: db}
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"alfredo/tabunganku/pkg/models"
+)
+
+var ErrVerificationTokenInvalid = errors.New("verification token is invalid or has expired")
+
+type EmailVerificationRepository interface {
+	CreateVerificationToken(userUuid string, tokenHash string, expiresAt time.Time) error
+	ConsumeVerificationToken(tokenHash string) (userUuid string, err error)
+}
+
+type emailVerificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// CreateVerificationToken implements EmailVerificationRepository.
+// Token lama yang belum dipakai langsung tidak berlaku, hanya email terakhir yang bisa dipakai.
+func (e *emailVerificationRepositoryImpl) CreateVerificationToken(userUuid string, tokenHash string, expiresAt time.Time) error {
+	return e.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.EmailVerificationToken{}).
+			Where("user_uuid = ? AND used_at IS NULL", userUuid).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		token := models.EmailVerificationToken{
+			UserUUID:  userUuid,
+			TokenHash: tokenHash,
+			ExpiresAt: expiresAt,
+		}
+		if err := tx.Create(&token).Error; err != nil {
+			return fmt.Errorf("failed to create verification token: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ConsumeVerificationToken implements EmailVerificationRepository.
+func (e *emailVerificationRepositoryImpl) ConsumeVerificationToken(tokenHash string) (userUuid string, err error) {
+	err = e.db.Transaction(func(tx *gorm.DB) error {
+		var token models.EmailVerificationToken
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+			First(&token).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrVerificationTokenInvalid
+			}
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(&token).Update("used_at", now).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.User{}).
+			Where("uuid = ? AND email_verified_at IS NULL", token.UserUUID).
+			Updates(map[string]interface{}{"email_verified_at": now, "updated_at": now}).Error; err != nil {
+			return fmt.Errorf("failed to verify email: %w", err)
+		}
+
+		userUuid = token.UserUUID
+		return nil
+	})
+
+	return userUuid, err
+}
+
+func NewEmailVerificationRepository(db *gorm.DB) EmailVerificationRepository {
+	return &emailVerificationRepositoryImpl{db: db}
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"alfredo/tabunganku/pkg/models"
+)
+
+var (
+	ErrIdentityNotFound          = errors.New("identity not found")
+	ErrIdentityUnverifiedAccount = errors.New("identity can only be linked to an account with a verified email")
+)
+
+type IdentityRepository interface {
+	FindIdentityUser(provider string, subject string) (*models.User, error)
+	LinkIdentity(user *models.User, identity *models.UserIdentity) error
+	CreateUserWithIdentity(user *models.User, identity *models.UserIdentity) error
+}
+
+type identityRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// FindIdentityUser implements IdentityRepository.
+func (i *identityRepositoryImpl) FindIdentityUser(provider string, subject string) (*models.User, error) {
+	var user models.User
+	err := i.db.Joins("JOIN user_identities ON user_identities.user_uuid = users.uuid").
+		Where("user_identities.provider = ? AND user_identities.subject = ?", provider, subject).
+		First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrIdentityNotFound
+		}
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return &user, nil
+}
+
+// LinkIdentity implements IdentityRepository.
+// Only accounts with a verified email may be linked, otherwise whoever registered the email first keeps access.
+func (i *identityRepositoryImpl) LinkIdentity(user *models.User, identity *models.UserIdentity) error {
+	if user.EmailVerifiedAt == nil {
+		return ErrIdentityUnverifiedAccount
+	}
+
+	identity.UserUUID = user.UUID
+	if err := i.db.Create(identity).Error; err != nil {
+		return fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	return nil
+}
+
+// CreateUserWithIdentity implements IdentityRepository.
+func (i *identityRepositoryImpl) CreateUserWithIdentity(user *models.User, identity *models.UserIdentity) error {
+	return i.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+
+		identity.UserUUID = user.UUID
+		if err := tx.Create(identity).Error; err != nil {
+			return fmt.Errorf("failed to link identity: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func NewIdentityRepository(db *gorm.DB) IdentityRepository {
+	return &identityRepositoryImpl{db: db}
+}
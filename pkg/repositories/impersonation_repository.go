@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/models"
+)
+
+var ErrImpersonationNotFound = errors.New("impersonation not found")
+
+type ImpersonationRepository interface {
+	CreateImpersonation(audit *models.ImpersonationAudit) error
+	FindActiveImpersonation(uuid string) (*models.ImpersonationAudit, error)
+	EndImpersonation(uuid string, endedAt time.Time) error
+	GetImpersonations(filter *dtos.ImpersonationFilter) ([]models.ImpersonationAudit, int64, error)
+}
+
+type impersonationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// CreateImpersonation implements ImpersonationRepository.
+func (i *impersonationRepositoryImpl) CreateImpersonation(audit *models.ImpersonationAudit) error {
+	if err := i.db.Create(audit).Error; err != nil {
+		return fmt.Errorf("failed to create impersonation audit: %w", err)
+	}
+
+	return nil
+}
+
+// FindActiveImpersonation implements ImpersonationRepository.
+func (i *impersonationRepositoryImpl) FindActiveImpersonation(uuid string) (*models.ImpersonationAudit, error) {
+	var audit models.ImpersonationAudit
+	if err := i.db.Where("uuid = ? AND ended_at IS NULL AND expires_at > ?", uuid, time.Now()).First(&audit).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrImpersonationNotFound
+		}
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return &audit, nil
+}
+
+// EndImpersonation implements ImpersonationRepository.
+func (i *impersonationRepositoryImpl) EndImpersonation(uuid string, endedAt time.Time) error {
+	result := i.db.Model(&models.ImpersonationAudit{}).
+		Where("uuid = ? AND ended_at IS NULL", uuid).
+		Update("ended_at", endedAt)
+	if result.Error != nil {
+		return fmt.Errorf("failed to end impersonation: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrImpersonationNotFound
+	}
+
+	return nil
+}
+
+// GetImpersonations implements ImpersonationRepository.
+func (i *impersonationRepositoryImpl) GetImpersonations(filter *dtos.ImpersonationFilter) ([]models.ImpersonationAudit, int64, error) {
+	query := i.db.Model(&models.ImpersonationAudit{})
+	if filter.AdminUuid != "" {
+		query = query.Where("admin_uuid = ?", filter.AdminUuid)
+	}
+	if filter.UserUuid != "" {
+		query = query.Where("user_uuid = ?", filter.UserUuid)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("please try again later")
+	}
+
+	var audits []models.ImpersonationAudit
+	if err := query.Order("created_at DESC").
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Find(&audits).Error; err != nil {
+		return nil, 0, fmt.Errorf("please try again later")
+	}
+
+	return audits, total, nil
+}
+
+func NewImpersonationRepository(db *gorm.DB) ImpersonationRepository {
+	return &impersonationRepositoryImpl{db: db}
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"alfredo/tabunganku/pkg/models"
+)
+
+var ErrMfaAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+type MfaRepository interface {
+	SetPendingSecret(userUuid string, secret string) error
+	EnableTotp(userUuid string, recoveryCodeHashes []string) error
+	DisableTotp(userUuid string) error
+	UseRecoveryCode(userUuid string, codeHash string) (bool, error)
+}
+
+type mfaRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// SetPendingSecret implements MfaRepository.
+// Secret baru belum aktif sampai dikonfirmasi dengan kode dari aplikasi authenticator.
+func (m *mfaRepositoryImpl) SetPendingSecret(userUuid string, secret string) error {
+	result := m.db.Model(&models.User{}).
+		Where("uuid = ? AND totp_enabled_at IS NULL", userUuid).
+		Updates(map[string]interface{}{"totp_secret": secret, "updated_at": time.Now()})
+	if result.Error != nil {
+		return fmt.Errorf("failed to store totp secret: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrMfaAlreadyEnabled
+	}
+
+	return nil
+}
+
+// EnableTotp implements MfaRepository.
+func (m *mfaRepositoryImpl) EnableTotp(userUuid string, recoveryCodeHashes []string) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.User{}).
+			Where("uuid = ? AND totp_enabled_at IS NULL AND totp_secret IS NOT NULL", userUuid).
+			Updates(map[string]interface{}{"totp_enabled_at": now, "updated_at": now})
+		if result.Error != nil {
+			return fmt.Errorf("failed to enable totp: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrMfaAlreadyEnabled
+		}
+
+		return replaceRecoveryCodes(tx, userUuid, recoveryCodeHashes)
+	})
+}
+
+// DisableTotp implements MfaRepository.
+func (m *mfaRepositoryImpl) DisableTotp(userUuid string) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).
+			Where("uuid = ?", userUuid).
+			Updates(map[string]interface{}{"totp_secret": nil, "totp_enabled_at": nil, "updated_at": time.Now()}).Error; err != nil {
+			return fmt.Errorf("failed to disable totp: %w", err)
+		}
+
+		return replaceRecoveryCodes(tx, userUuid, nil)
+	})
+}
+
+// UseRecoveryCode implements MfaRepository.
+func (m *mfaRepositoryImpl) UseRecoveryCode(userUuid string, codeHash string) (bool, error) {
+	result := m.db.Model(&models.UserRecoveryCode{}).
+		Where("user_uuid = ? AND code_hash = ? AND used_at IS NULL", userUuid, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// replaceRecoveryCodes removes every recovery code of the user and stores the new ones
+func replaceRecoveryCodes(tx *gorm.DB, userUuid string, codeHashes []string) error {
+	if err := tx.Where("user_uuid = ?", userUuid).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+		return err
+	}
+
+	if len(codeHashes) == 0 {
+		return nil
+	}
+
+	codes := make([]models.UserRecoveryCode, 0, len(codeHashes))
+	for _, codeHash := range codeHashes {
+		codes = append(codes, models.UserRecoveryCode{UserUUID: userUuid, CodeHash: codeHash})
+	}
+	if err := tx.Create(&codes).Error; err != nil {
+		return fmt.Errorf("failed to create recovery codes: %w", err)
+	}
+
+	return nil
+}
+
+func NewMfaRepository(db *gorm.DB) MfaRepository {
+	return &mfaRepositoryImpl{db: db}
+}
@@ -0,0 +1,221 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/models"
+)
+
+// DefaultMilestones are used for savings without custom milestone definitions
+var DefaultMilestones = []float64{25, 50, 75, 100}
+
+type MilestoneRepository interface {
+	ReplaceMilestones(userUuid string, savingUuid string, milestones []dtos.MilestoneRequest) (*dtos.SavingTimelineResponse, error)
+	GetTimeline(userUuid string, savingUuid string) (*dtos.SavingTimelineResponse, error)
+}
+
+type milestoneRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// ReplaceMilestones implements MilestoneRepository.
+func (m *milestoneRepositoryImpl) ReplaceMilestones(userUuid string, savingUuid string, milestones []dtos.MilestoneRequest) (*dtos.SavingTimelineResponse, error) {
+	savingModel, err := m.findSaving(userUuid, savingUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("saving_uuid = ?", savingModel.UUID).Delete(&models.SavingMilestone{}).Error; err != nil {
+			return err
+		}
+
+		milestoneModels := make([]models.SavingMilestone, 0, len(milestones))
+		for _, milestone := range milestones {
+			milestoneModel := models.SavingMilestone{
+				SavingUUID: savingModel.UUID,
+				Percentage: milestone.Percentage,
+			}
+			if milestone.Label != "" {
+				label := milestone.Label
+				milestoneModel.Label = &label
+			}
+			milestoneModels = append(milestoneModels, milestoneModel)
+		}
+
+		if err := tx.Create(&milestoneModels).Error; err != nil {
+			return fmt.Errorf("failed to create milestones: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m.buildTimeline(savingModel)
+}
+
+// GetTimeline implements MilestoneRepository.
+func (m *milestoneRepositoryImpl) GetTimeline(userUuid string, savingUuid string) (*dtos.SavingTimelineResponse, error) {
+	savingModel, err := m.findSaving(userUuid, savingUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.buildTimeline(savingModel)
+}
+
+// buildTimeline combines the milestone definitions, the current progress and the reached events
+func (m *milestoneRepositoryImpl) buildTimeline(savingModel *models.Saving) (*dtos.SavingTimelineResponse, error) {
+	milestones, err := savingMilestones(m.db, savingModel.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := savingBalances(m.db, []string{savingModel.UUID})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []models.MilestoneEvent
+	if err := m.db.Where("saving_uuid = ?", savingModel.UUID).Order("reached_at ASC").Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	response := &dtos.SavingTimelineResponse{
+		SavingUUID:   savingModel.UUID,
+		Name:         savingModel.Name,
+		TargetAmount: savingModel.TargetAmount,
+		Collected:    balances[savingModel.UUID],
+		Milestones:   make([]dtos.MilestoneResponse, 0, len(milestones)),
+		Events:       make([]dtos.MilestoneEventResponse, 0, len(events)),
+	}
+	if savingModel.TargetAmount > 0 {
+		response.Progress = response.Collected / savingModel.TargetAmount * 100
+	}
+
+	for _, milestone := range milestones {
+		item := dtos.MilestoneResponse{
+			Percentage: milestone.Percentage,
+			Label:      milestoneLabel(&milestone),
+			Amount:     milestoneAmount(savingModel, milestone.Percentage),
+		}
+		item.Reached = response.Collected-item.Amount > -0.005
+		for _, event := range events {
+			if event.Percentage == milestone.Percentage {
+				item.Reached = true
+				item.ReachedAt = event.ReachedAt
+			}
+		}
+		response.Milestones = append(response.Milestones, item)
+	}
+
+	for i := range events {
+		response.Events = append(response.Events, *toMilestoneEventResponse(&events[i]))
+	}
+
+	return response, nil
+}
+
+// findSaving returns the saving model owned by the user
+func (m *milestoneRepositoryImpl) findSaving(userUuid string, savingUuid string) (*models.Saving, error) {
+	var savingModel models.Saving
+	if err := m.db.First(&savingModel, "uuid = ? AND user_uuid = ?", savingUuid, userUuid).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSavingNotFound
+		}
+		return nil, err
+	}
+
+	return &savingModel, nil
+}
+
+// savingMilestones returns the milestone definitions of a saving, falling back to DefaultMilestones
+func savingMilestones(db *gorm.DB, savingUuid string) ([]models.SavingMilestone, error) {
+	var milestones []models.SavingMilestone
+	if err := db.Where("saving_uuid = ?", savingUuid).Order("percentage ASC").Find(&milestones).Error; err != nil {
+		return nil, err
+	}
+
+	if len(milestones) == 0 {
+		for _, percentage := range DefaultMilestones {
+			milestones = append(milestones, models.SavingMilestone{SavingUUID: savingUuid, Percentage: percentage})
+		}
+	}
+
+	return milestones, nil
+}
+
+// recordMilestones stores an event for every milestone crossed when the balance moved from before to after.
+// A milestone is only recorded once, even if the balance drops and crosses it again.
+func recordMilestones(tx *gorm.DB, savingModel *models.Saving, before float64, after float64, transaction *models.SavingTransaction) ([]models.MilestoneEvent, error) {
+	milestones, err := savingMilestones(tx, savingModel.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []models.MilestoneEvent
+	for _, milestone := range milestones {
+		threshold := milestoneAmount(savingModel, milestone.Percentage)
+		if before-threshold > -0.005 || after-threshold <= -0.005 {
+			continue
+		}
+
+		event := models.MilestoneEvent{
+			SavingUUID:            savingModel.UUID,
+			UserUUID:              savingModel.UserUUID,
+			Percentage:            milestone.Percentage,
+			Label:                 milestoneLabel(&milestone),
+			Amount:                threshold,
+			SavingTransactionUUID: transaction.UUID,
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&event)
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to record milestone: %w", result.Error)
+		}
+		if result.RowsAffected > 0 {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+func milestoneAmount(savingModel *models.Saving, percentage float64) float64 {
+	return savingModel.TargetAmount * percentage / 100
+}
+
+func milestoneLabel(milestone *models.SavingMilestone) string {
+	if milestone.Label != nil && *milestone.Label != "" {
+		return *milestone.Label
+	}
+
+	return fmt.Sprintf("%g%% of target reached", milestone.Percentage)
+}
+
+func toMilestoneEventResponse(event *models.MilestoneEvent) *dtos.MilestoneEventResponse {
+	response := &dtos.MilestoneEventResponse{
+		UUID:                  event.UUID,
+		SavingUUID:            event.SavingUUID,
+		Percentage:            event.Percentage,
+		Label:                 event.Label,
+		Amount:                event.Amount,
+		SavingTransactionUUID: event.SavingTransactionUUID,
+	}
+	if event.ReachedAt != nil {
+		response.ReachedAt = *event.ReachedAt
+	}
+
+	return response
+}
+
+func NewMilestoneRepository(db *gorm.DB) MilestoneRepository {
+	return &milestoneRepositoryImpl{db: db}
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"alfredo/tabunganku/pkg/models"
+)
+
+var ErrResetTokenInvalid = errors.New("reset token is invalid or has expired")
+
+type PasswordResetRepository interface {
+	CreateResetToken(userUuid string, tokenHash string, expiresAt time.Time) error
+	FindResetToken(tokenHash string) (*models.PasswordResetToken, error)
+	ConsumeResetToken(tokenHash string, passwordHash string) (userUuid string, err error)
+}
+
+type passwordResetRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// CreateResetToken implements PasswordResetRepository.
+// Token lama yang belum dipakai langsung tidak berlaku, hanya token terakhir yang bisa dipakai.
+func (p *passwordResetRepositoryImpl) CreateResetToken(userUuid string, tokenHash string, expiresAt time.Time) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.PasswordResetToken{}).
+			Where("user_uuid = ? AND used_at IS NULL", userUuid).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		token := models.PasswordResetToken{
+			UserUUID:  userUuid,
+			TokenHash: tokenHash,
+			ExpiresAt: expiresAt,
+		}
+		if err := tx.Create(&token).Error; err != nil {
+			return fmt.Errorf("failed to create reset token: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// FindResetToken implements PasswordResetRepository.
+// Only an unused token that has not expired is returned.
+func (p *passwordResetRepositoryImpl) FindResetToken(tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	if err := p.db.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+		First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrResetTokenInvalid
+		}
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return &token, nil
+}
+
+// ConsumeResetToken implements PasswordResetRepository.
+func (p *passwordResetRepositoryImpl) ConsumeResetToken(tokenHash string, passwordHash string) (userUuid string, err error) {
+	err = p.db.Transaction(func(tx *gorm.DB) error {
+		// Kunci token agar tidak bisa dipakai dua kali secara bersamaan
+		var token models.PasswordResetToken
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+			First(&token).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrResetTokenInvalid
+			}
+			return err
+		}
+
+		if err := tx.Model(&token).Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.User{}).
+			Where("uuid = ?", token.UserUUID).
+			Updates(map[string]interface{}{"password": passwordHash, "updated_at": time.Now()})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update password: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrResetTokenInvalid
+		}
+
+		userUuid = token.UserUUID
+		return nil
+	})
+
+	return userUuid, err
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepositoryImpl{db: db}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -15,7 +16,12 @@ type redisRepositoryImpl struct {
 type RedisRepository interface {
 	GetClient() *redis.Client
 	Set(key string, value interface{}) error
+	SetWithExpiration(key string, value interface{}, expiration time.Duration) error
+	SetIfNotExists(key string, value interface{}, expiration time.Duration) (bool, error)
 	Get(key string) (string, error)
+	Increment(key string) (int64, error)
+	Expire(key string, expiration time.Duration) error
+	TTL(key string) (time.Duration, error)
 	Delete(key string) error
 }
 
@@ -38,6 +44,25 @@ func (r *redisRepositoryImpl) Set(key string, value interface{}) error {
 	return nil
 }
 
+func (r *redisRepositoryImpl) SetWithExpiration(key string, value interface{}, expiration time.Duration) error {
+	ctx := context.Background()
+	err := r.client.Set(ctx, key, value, expiration).Err()
+	if err != nil {
+		return errors.New(fmt.Sprint("Please contact our customer service."))
+	}
+	return nil
+}
+
+// SetIfNotExists stores the key only when it is missing and reports whether it was stored
+func (r *redisRepositoryImpl) SetIfNotExists(key string, value interface{}, expiration time.Duration) (bool, error) {
+	ctx := context.Background()
+	ok, err := r.client.SetNX(ctx, key, value, expiration).Result()
+	if err != nil {
+		return false, errors.New(fmt.Sprint("Please contact our customer service."))
+	}
+	return ok, nil
+}
+
 func (r *redisRepositoryImpl) Get(key string) (string, error) {
 	ctx := context.Background()
 	val, err := r.client.Get(ctx, key).Result()
@@ -47,6 +72,34 @@ func (r *redisRepositoryImpl) Get(key string) (string, error) {
 	return val, nil
 }
 
+func (r *redisRepositoryImpl) Increment(key string) (int64, error) {
+	ctx := context.Background()
+	val, err := r.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, errors.New(fmt.Sprint("Please contact our customer service."))
+	}
+	return val, nil
+}
+
+func (r *redisRepositoryImpl) Expire(key string, expiration time.Duration) error {
+	ctx := context.Background()
+	err := r.client.Expire(ctx, key, expiration).Err()
+	if err != nil {
+		return errors.New(fmt.Sprint("Please contact our customer service."))
+	}
+	return nil
+}
+
+// TTL returns the time left before the key expires, negative when the key is missing or has no expiry
+func (r *redisRepositoryImpl) TTL(key string) (time.Duration, error) {
+	ctx := context.Background()
+	ttl, err := r.client.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, errors.New(fmt.Sprint("Please contact our customer service."))
+	}
+	return ttl, nil
+}
+
 func (r *redisRepositoryImpl) Delete(key string) error {
 	ctx := context.Background()
 	err := r.client.Del(ctx, key).Err()
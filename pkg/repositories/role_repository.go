@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/models"
+)
+
+var ErrRoleNotFound = errors.New("role not found")
+
+type RoleRepository interface {
+	GetRoles() ([]dtos.RoleResponse, error)
+	GetUserRoles(userUuid string) ([]string, error)
+	GetUserPermissions(userUuid string) ([]string, error)
+	AssignRole(userUuid string, roleName string) error
+	RemoveRole(userUuid string, roleName string) error
+}
+
+type roleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// GetRoles implements RoleRepository.
+func (r *roleRepositoryImpl) GetRoles() ([]dtos.RoleResponse, error) {
+	var roles []models.Role
+	if err := r.db.Order("name ASC").Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	var rows []struct {
+		RoleUUID string
+		Name     string
+	}
+	err := r.db.Table("role_permissions").
+		Select("role_permissions.role_uuid, permissions.name").
+		Joins("JOIN permissions ON permissions.uuid = role_permissions.permission_uuid").
+		Order("permissions.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	permissions := make(map[string][]string)
+	for _, row := range rows {
+		permissions[row.RoleUUID] = append(permissions[row.RoleUUID], row.Name)
+	}
+
+	responses := make([]dtos.RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		response := dtos.RoleResponse{
+			UUID:        role.UUID,
+			Name:        role.Name,
+			Permissions: permissions[role.UUID],
+		}
+		if role.Description != nil {
+			response.Description = *role.Description
+		}
+		if response.Permissions == nil {
+			response.Permissions = []string{}
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// GetUserRoles implements RoleRepository.
+func (r *roleRepositoryImpl) GetUserRoles(userUuid string) ([]string, error) {
+	roles := []string{}
+	err := r.db.Table("user_roles").
+		Joins("JOIN roles ON roles.uuid = user_roles.role_uuid").
+		Where("user_roles.user_uuid = ?", userUuid).
+		Order("roles.name ASC").
+		Pluck("roles.name", &roles).Error
+	if err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return roles, nil
+}
+
+// GetUserPermissions implements RoleRepository.
+func (r *roleRepositoryImpl) GetUserPermissions(userUuid string) ([]string, error) {
+	permissions := []string{}
+	err := r.db.Table("user_roles").
+		Distinct("permissions.name").
+		Joins("JOIN role_permissions ON role_permissions.role_uuid = user_roles.role_uuid").
+		Joins("JOIN permissions ON permissions.uuid = role_permissions.permission_uuid").
+		Where("user_roles.user_uuid = ?", userUuid).
+		Pluck("permissions.name", &permissions).Error
+	if err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return permissions, nil
+}
+
+// AssignRole implements RoleRepository.
+func (r *roleRepositoryImpl) AssignRole(userUuid string, roleName string) error {
+	role, err := r.findRole(roleName)
+	if err != nil {
+		return err
+	}
+
+	userRole := models.UserRole{UserUUID: userUuid, RoleUUID: role.UUID}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&userRole).Error; err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveRole implements RoleRepository.
+func (r *roleRepositoryImpl) RemoveRole(userUuid string, roleName string) error {
+	role, err := r.findRole(roleName)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.Where("user_uuid = ? AND role_uuid = ?", userUuid, role.UUID).Delete(&models.UserRole{}).Error; err != nil {
+		return fmt.Errorf("failed to remove role: %w", err)
+	}
+
+	return nil
+}
+
+func (r *roleRepositoryImpl) findRole(name string) (*models.Role, error) {
+	var role models.Role
+	if err := r.db.Where("name = ?", name).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return &role, nil
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepositoryImpl{db: db}
+}
@@ -0,0 +1,311 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/models"
+)
+
+var (
+	ErrRuleNotFound   = errors.New("rule not found")
+	ErrCashflowExists = errors.New("cashflow with this external id already posted")
+)
+
+// RuleEvaluator returns the amount a rule should deposit for an event, zero when the rule does not apply
+type RuleEvaluator func(rule *models.SavingRule, event *models.CashflowEvent) float64
+
+type RuleRepository interface {
+	CreateRule(req *dtos.SavingRuleRequest) (*dtos.SavingRuleResponse, error)
+	GetRules(userUuid string) ([]*dtos.SavingRuleResponse, error)
+	UpdateRule(uuid string, req *dtos.SavingRuleRequest) (*dtos.SavingRuleResponse, error)
+	DeleteRule(userUuid string, uuid string) error
+	GetRuleExecutions(userUuid string, ruleUuid string) ([]*dtos.RuleExecutionResponse, error)
+	PostCashflow(req *dtos.CashflowRequest, evaluate RuleEvaluator) (*dtos.CashflowResponse, error)
+}
+
+type ruleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// CreateRule implements RuleRepository.
+func (r *ruleRepositoryImpl) CreateRule(req *dtos.SavingRuleRequest) (*dtos.SavingRuleResponse, error) {
+	if err := r.ensureSavingOwner(req.UserUUID, req.SavingUUID); err != nil {
+		return nil, err
+	}
+
+	rule := models.SavingRule{
+		UserUUID:   req.UserUUID,
+		SavingUUID: req.SavingUUID,
+		Name:       req.Name,
+		Type:       req.Type,
+		RoundTo:    req.RoundTo,
+		Percentage: req.Percentage,
+		IsActive:   req.IsActive == nil || *req.IsActive,
+	}
+
+	if err := r.db.Create(&rule).Error; err != nil {
+		return nil, fmt.Errorf("failed to create rule: %w", err)
+	}
+
+	// Gorm melewati nilai false untuk kolom yang punya default, jadi set manual
+	if req.IsActive != nil && !*req.IsActive {
+		if err := r.db.Model(&rule).Update("is_active", false).Error; err != nil {
+			return nil, fmt.Errorf("failed to create rule: %w", err)
+		}
+	}
+
+	return toSavingRuleResponse(&rule), nil
+}
+
+// GetRules implements RuleRepository.
+func (r *ruleRepositoryImpl) GetRules(userUuid string) ([]*dtos.SavingRuleResponse, error) {
+	var rules []models.SavingRule
+	if err := r.db.Where("user_uuid = ?", userUuid).Order("created_at ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	response := make([]*dtos.SavingRuleResponse, 0, len(rules))
+	for i := range rules {
+		response = append(response, toSavingRuleResponse(&rules[i]))
+	}
+
+	return response, nil
+}
+
+// UpdateRule implements RuleRepository.
+func (r *ruleRepositoryImpl) UpdateRule(uuid string, req *dtos.SavingRuleRequest) (*dtos.SavingRuleResponse, error) {
+	rule, err := r.findRule(req.UserUUID, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.ensureSavingOwner(req.UserUUID, req.SavingUUID); err != nil {
+		return nil, err
+	}
+
+	rule.SavingUUID = req.SavingUUID
+	rule.Name = req.Name
+	rule.Type = req.Type
+	rule.RoundTo = req.RoundTo
+	rule.Percentage = req.Percentage
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+
+	if err := r.db.Model(rule).
+		Select("saving_uuid", "name", "type", "round_to", "percentage", "is_active", "updated_at").
+		Updates(rule).Error; err != nil {
+		return nil, fmt.Errorf("failed to update rule: %w", err)
+	}
+
+	return toSavingRuleResponse(rule), nil
+}
+
+// DeleteRule implements RuleRepository.
+func (r *ruleRepositoryImpl) DeleteRule(userUuid string, uuid string) error {
+	rule, err := r.findRule(userUuid, uuid)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.Delete(rule).Error; err != nil {
+		return fmt.Errorf("failed to delete rule: %w", err)
+	}
+
+	return nil
+}
+
+// GetRuleExecutions implements RuleRepository.
+func (r *ruleRepositoryImpl) GetRuleExecutions(userUuid string, ruleUuid string) ([]*dtos.RuleExecutionResponse, error) {
+	rule, err := r.findRule(userUuid, ruleUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		models.RuleExecution
+		SavingUUID string
+	}
+
+	err = r.db.Table("rule_executions").
+		Select("rule_executions.*, saving_transactions.saving_uuid").
+		Joins("JOIN saving_transactions ON saving_transactions.uuid = rule_executions.saving_transaction_uuid").
+		Where("rule_executions.rule_uuid = ?", rule.UUID).
+		Order("rule_executions.created_at DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dtos.RuleExecutionResponse, 0, len(rows))
+	for _, row := range rows {
+		response = append(response, toRuleExecutionResponse(&row.RuleExecution, row.SavingUUID))
+	}
+
+	return response, nil
+}
+
+// PostCashflow implements RuleRepository.
+func (r *ruleRepositoryImpl) PostCashflow(req *dtos.CashflowRequest, evaluate RuleEvaluator) (*dtos.CashflowResponse, error) {
+	event := models.CashflowEvent{
+		UserUUID:     req.UserUUID,
+		Type:         req.Type,
+		Amount:       req.Amount,
+		CurrencyCode: req.CurrencyCode,
+		OccurredAt:   time.Now(),
+	}
+	if req.Description != "" {
+		event.Description = &req.Description
+	}
+	if req.ExternalID != "" {
+		event.ExternalID = &req.ExternalID
+	}
+	if req.OccurredAt != nil {
+		event.OccurredAt = *req.OccurredAt
+	}
+
+	executions := []dtos.RuleExecutionResponse{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Unique index (user_uuid, external_id) yang menjaga idempotensi, termasuk untuk request yang bersamaan
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&event)
+		if result.Error != nil {
+			return fmt.Errorf("failed to create cashflow: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrCashflowExists
+		}
+
+		// Hanya rule aktif yang tabungannya belum selesai dan memakai mata uang yang sama
+		var rules []models.SavingRule
+		if err := tx.Joins("JOIN savings ON savings.uuid = saving_rules.saving_uuid AND savings.deleted_at IS NULL").
+			Where("saving_rules.user_uuid = ? AND saving_rules.is_active = TRUE", event.UserUUID).
+			Where("savings.currency_code = ? AND savings.is_completed IS NOT TRUE", event.CurrencyCode).
+			Order("saving_rules.created_at ASC").
+			Find(&rules).Error; err != nil {
+			return err
+		}
+
+		for i := range rules {
+			amount := evaluate(&rules[i], &event)
+			if amount <= 0 {
+				continue
+			}
+
+			note := fmt.Sprintf("Rule: %s", rules[i].Name)
+			transaction := models.SavingTransaction{
+				SavingUUID:    rules[i].SavingUUID,
+				UserUUID:      event.UserUUID,
+				Type:          TransactionTypeDeposit,
+				Amount:        amount,
+				Source:        TransactionSourceRule,
+				ReferenceUUID: &event.UUID,
+				Note:          &note,
+			}
+			if _, err := postTransaction(tx, &transaction); err != nil {
+				return err
+			}
+
+			execution := models.RuleExecution{
+				RuleUUID:              rules[i].UUID,
+				CashflowEventUUID:     event.UUID,
+				SavingTransactionUUID: transaction.UUID,
+				Amount:                amount,
+			}
+			if err := tx.Create(&execution).Error; err != nil {
+				return fmt.Errorf("failed to record rule execution: %w", err)
+			}
+
+			executions = append(executions, *toRuleExecutionResponse(&execution, transaction.SavingUUID))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dtos.CashflowResponse{
+		UUID:         event.UUID,
+		Type:         event.Type,
+		Amount:       event.Amount,
+		CurrencyCode: event.CurrencyCode,
+		Description:  req.Description,
+		ExternalID:   req.ExternalID,
+		OccurredAt:   event.OccurredAt,
+		Executions:   executions,
+	}
+
+	return response, nil
+}
+
+// findRule returns the rule model owned by the user
+func (r *ruleRepositoryImpl) findRule(userUuid string, uuid string) (*models.SavingRule, error) {
+	var rule models.SavingRule
+	if err := r.db.Where("uuid = ? AND user_uuid = ?", uuid, userUuid).First(&rule).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRuleNotFound
+		}
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return &rule, nil
+}
+
+// ensureSavingOwner makes sure the saving exists and belongs to the user
+func (r *ruleRepositoryImpl) ensureSavingOwner(userUuid string, savingUuid string) error {
+	var count int64
+	if err := r.db.Model(&models.Saving{}).Where("uuid = ? AND user_uuid = ?", savingUuid, userUuid).Count(&count).Error; err != nil {
+		return fmt.Errorf("please try again later")
+	}
+	if count == 0 {
+		return ErrSavingNotFound
+	}
+
+	return nil
+}
+
+func toSavingRuleResponse(rule *models.SavingRule) *dtos.SavingRuleResponse {
+	response := &dtos.SavingRuleResponse{
+		UUID:       rule.UUID,
+		SavingUUID: rule.SavingUUID,
+		Name:       rule.Name,
+		Type:       rule.Type,
+		RoundTo:    rule.RoundTo,
+		Percentage: rule.Percentage,
+		IsActive:   rule.IsActive,
+	}
+	if rule.CreatedAt != nil {
+		response.CreatedAt = *rule.CreatedAt
+	}
+	if rule.UpdatedAt != nil {
+		response.UpdatedAt = *rule.UpdatedAt
+	}
+
+	return response
+}
+
+func toRuleExecutionResponse(execution *models.RuleExecution, savingUuid string) *dtos.RuleExecutionResponse {
+	response := &dtos.RuleExecutionResponse{
+		UUID:                  execution.UUID,
+		RuleUUID:              execution.RuleUUID,
+		SavingUUID:            savingUuid,
+		CashflowEventUUID:     execution.CashflowEventUUID,
+		SavingTransactionUUID: execution.SavingTransactionUUID,
+		Amount:                execution.Amount,
+	}
+	if execution.CreatedAt != nil {
+		response.CreatedAt = *execution.CreatedAt
+	}
+
+	return response
+}
+
+func NewRuleRepository(db *gorm.DB) RuleRepository {
+	return &ruleRepositoryImpl{db: db}
+}
@@ -1,15 +1,28 @@
 package repositories
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"alfredo/tabunganku/pkg/dtos"
 	"alfredo/tabunganku/pkg/models"
 )
 
+var (
+	ErrSavingNotFound  = errors.New("saving not found")
+	ErrInvalidDeadline = errors.New("deadline must use the YYYY-MM-DD format")
+)
+
 type SavingRepository interface {
 	CreateSaving(saving *dtos.SavingRequest) (response *dtos.SavingResponse, err error)
-	GetSavings(userUuid string) (response []*dtos.SavingResponse, err error)
+	GetSavings(userUuid string, filter *dtos.SavingFilter) (response []*dtos.SavingResponse, err error)
+	GetSummary(userUuid string) (response *dtos.SavingSummaryResponse, err error)
+	ReplaceSavingTags(userUuid string, savingUuid string, tags []string) (response []string, err error)
 }
 
 type savingRepositoryImpl struct {
@@ -27,10 +40,41 @@ func (s *savingRepositoryImpl) CreateSaving(saving *dtos.SavingRequest) (respons
 		Image:          saving.Image,
 		FillingPlan:    saving.FillingPlan,
 		FillingNominal: saving.FillingNominal,
+		Priority:       saving.Priority,
+	}
+
+	if saving.Deadline != "" {
+		deadline, err := time.Parse(time.DateOnly, saving.Deadline)
+		if err != nil {
+			return nil, ErrInvalidDeadline
+		}
+		savingModel.Deadline = &deadline
+	}
+
+	// Pastikan kategori milik user yang sama
+	var categoryModel *models.Category
+	if saving.CategoryUUID != "" {
+		categoryModel = &models.Category{}
+		err = s.db.First(categoryModel, "uuid = ? AND user_uuid = ?", saving.CategoryUUID, saving.UserUUID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrCategoryNotFound
+			}
+			return nil, err
+		}
+		savingModel.CategoryUUID = &categoryModel.UUID
 	}
 
-	// Create saving ke database
-	err = s.db.Create(&savingModel).Error
+	// Create saving dan tags ke database
+	var tags []string
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&savingModel).Error; err != nil {
+			return err
+		}
+
+		tags, err = s.attachTags(tx, saving.UserUUID, savingModel.UUID, saving.Tags)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -49,31 +93,24 @@ func (s *savingRepositoryImpl) CreateSaving(saving *dtos.SavingRequest) (respons
 		return nil, err
 	}
 
-	return &dtos.SavingResponse{
-		UUID: savingModel.UUID,
-		User: dtos.UserResponse{
-			UUID:        userModel.UUID,
-			Name:        userModel.Name,
-			Email:       userModel.Email,
-			PhoneNumber: *userModel.PhoneNumber,
-			Image:       *userModel.Photo,
-		},
-		Name:           savingModel.Name,
-		TargetAmount:   savingModel.TargetAmount,
-		CurrencyCode:   savingModel.CurrencyCode,
-		CurrencyFlag:   currencyModel.CountryFlag,
-		Image:          savingModel.Image,
-		FillingPlan:    savingModel.FillingPlan,
-		FillingNominal: savingModel.FillingNominal,
-		CreatedAt:      *savingModel.CreatedAt,
-		UpdatedAt:      *savingModel.UpdatedAt,
-	}, nil
+	return toSavingResponse(&savingModel, &userModel, &currencyModel, categoryModel, tags, 0), nil
 }
 
 // GetSavings implements SavingRepository.
-func (s *savingRepositoryImpl) GetSavings(userUuid string) (response []*dtos.SavingResponse, err error) {
+func (s *savingRepositoryImpl) GetSavings(userUuid string, filter *dtos.SavingFilter) (response []*dtos.SavingResponse, err error) {
+	query := s.db.Where("user_uuid = ?", userUuid)
+	if filter != nil && filter.CategoryUUID != "" {
+		query = query.Where("category_uuid = ?", filter.CategoryUUID)
+	}
+	if filter != nil && filter.Tag != "" {
+		query = query.Where("uuid IN (?)", s.db.Table("saving_tags").
+			Select("saving_tags.saving_uuid").
+			Joins("JOIN tags ON tags.uuid = saving_tags.tag_uuid").
+			Where("tags.user_uuid = ? AND tags.name = ?", userUuid, normalizeTag(filter.Tag)))
+	}
+
 	var savingModels []models.Saving
-	err = s.db.Where("user_uuid = ?", userUuid).Find(&savingModels).Error
+	err = query.Find(&savingModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +127,27 @@ func (s *savingRepositoryImpl) GetSavings(userUuid string) (response []*dtos.Sav
 		return nil, err
 	}
 
+	var categoryModels []models.Category
+	err = s.db.Where("user_uuid = ?", userUuid).Find(&categoryModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	savingUuids := make([]string, 0, len(savingModels))
+	for _, savingModel := range savingModels {
+		savingUuids = append(savingUuids, savingModel.UUID)
+	}
+
+	tagsBySaving, err := s.findTags(savingUuids)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := savingBalances(s.db, savingUuids)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, savingModel := range savingModels {
 		var currencyModel models.Currency
 		for _, currency := range currencyModels {
@@ -98,30 +156,209 @@ func (s *savingRepositoryImpl) GetSavings(userUuid string) (response []*dtos.Sav
 			}
 		}
 
-		response = append(response, &dtos.SavingResponse{
-			UUID: savingModel.UUID,
-			User: dtos.UserResponse{
-				UUID:        userModel.UUID,
-				Name:        userModel.Name,
-				Email:       userModel.Email,
-				PhoneNumber: *userModel.PhoneNumber,
-				Image:       *userModel.Photo,
-			},
-			Name:           savingModel.Name,
-			TargetAmount:   savingModel.TargetAmount,
-			CurrencyCode:   savingModel.CurrencyCode,
-			CurrencyFlag:   currencyModel.CountryFlag,
-			Image:          savingModel.Image,
-			FillingPlan:    savingModel.FillingPlan,
-			FillingNominal: savingModel.FillingNominal,
-			CreatedAt:      *savingModel.CreatedAt,
-			UpdatedAt:      *savingModel.UpdatedAt,
-		})
+		var categoryModel *models.Category
+		for i, category := range categoryModels {
+			if savingModel.CategoryUUID != nil && category.UUID == *savingModel.CategoryUUID {
+				categoryModel = &categoryModels[i]
+			}
+		}
+
+		response = append(response, toSavingResponse(&savingModel, &userModel, &currencyModel, categoryModel, tagsBySaving[savingModel.UUID], balances[savingModel.UUID]))
+	}
+
+	return response, nil
+}
+
+// GetSummary implements SavingRepository.
+func (s *savingRepositoryImpl) GetSummary(userUuid string) (response *dtos.SavingSummaryResponse, err error) {
+	var rows []struct {
+		CategoryUUID   *string
+		Name           *string
+		Color          *string
+		Icon           *string
+		TotalSavings   int64
+		TotalTarget    float64
+		TotalCollected float64
+	}
+
+	balances := s.db.Model(&models.SavingTransaction{}).
+		Select("saving_uuid, SUM(CASE WHEN type = ? THEN amount ELSE -amount END) AS balance", TransactionTypeDeposit).
+		Group("saving_uuid")
+
+	err = s.db.Table("savings").
+		Select("savings.category_uuid, categories.name, categories.color, categories.icon, COUNT(savings.uuid) AS total_savings, COALESCE(SUM(savings.target_amount), 0) AS total_target, COALESCE(SUM(balances.balance), 0) AS total_collected").
+		Joins("LEFT JOIN categories ON categories.uuid = savings.category_uuid AND categories.deleted_at IS NULL").
+		Joins("LEFT JOIN (?) AS balances ON balances.saving_uuid = savings.uuid", balances).
+		Where("savings.user_uuid = ? AND savings.deleted_at IS NULL", userUuid).
+		Group("savings.category_uuid, categories.name, categories.color, categories.icon").
+		Order("categories.name ASC NULLS LAST").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	response = &dtos.SavingSummaryResponse{Categories: []dtos.CategoryTotalResponse{}}
+	for _, row := range rows {
+		total := dtos.CategoryTotalResponse{
+			Name:           "Uncategorized",
+			TotalSavings:   row.TotalSavings,
+			TotalTarget:    row.TotalTarget,
+			TotalCollected: row.TotalCollected,
+		}
+		if row.CategoryUUID != nil && row.Name != nil {
+			total.CategoryUUID = *row.CategoryUUID
+			total.Name = *row.Name
+			total.Color = *row.Color
+			total.Icon = *row.Icon
+		}
+
+		response.TotalSavings += row.TotalSavings
+		response.TotalTarget += row.TotalTarget
+		response.TotalCollected += row.TotalCollected
+		response.Categories = append(response.Categories, total)
 	}
 
 	return response, nil
 }
 
+// ReplaceSavingTags implements SavingRepository.
+func (s *savingRepositoryImpl) ReplaceSavingTags(userUuid string, savingUuid string, tags []string) (response []string, err error) {
+	var savingModel models.Saving
+	if err = s.db.First(&savingModel, "uuid = ? AND user_uuid = ?", savingUuid, userUuid).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSavingNotFound
+		}
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("saving_uuid = ?", savingModel.UUID).Delete(&models.SavingTag{}).Error; err != nil {
+			return err
+		}
+
+		response, err = s.attachTags(tx, userUuid, savingModel.UUID, tags)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// attachTags creates missing tags for the user and links them to the saving
+func (s *savingRepositoryImpl) attachTags(tx *gorm.DB, userUuid string, savingUuid string, tags []string) ([]string, error) {
+	names := normalizeTags(tags)
+	if len(names) == 0 {
+		return names, nil
+	}
+
+	tagModels := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		tagModels = append(tagModels, models.Tag{UserUUID: userUuid, Name: name})
+	}
+
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tagModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to create tags: %w", err)
+	}
+
+	// Ambil ulang karena tag yang sudah ada tidak mengembalikan uuid
+	if err := tx.Where("user_uuid = ? AND name IN ?", userUuid, names).Find(&tagModels).Error; err != nil {
+		return nil, err
+	}
+
+	savingTags := make([]models.SavingTag, 0, len(tagModels))
+	for _, tag := range tagModels {
+		savingTags = append(savingTags, models.SavingTag{SavingUUID: savingUuid, TagUUID: tag.UUID})
+	}
+
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&savingTags).Error; err != nil {
+		return nil, fmt.Errorf("failed to attach tags: %w", err)
+	}
+
+	return names, nil
+}
+
+// findTags returns the tag names of each saving, keyed by saving uuid
+func (s *savingRepositoryImpl) findTags(savingUuids []string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	if len(savingUuids) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		SavingUUID string
+		Name       string
+	}
+
+	err := s.db.Table("saving_tags").
+		Select("saving_tags.saving_uuid, tags.name").
+		Joins("JOIN tags ON tags.uuid = saving_tags.tag_uuid").
+		Where("saving_tags.saving_uuid IN ?", savingUuids).
+		Order("tags.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.SavingUUID] = append(result[row.SavingUUID], row.Name)
+	}
+
+	return result, nil
+}
+
+// normalizeTag lowercases and trims a tag so "Liburan " and "liburan" are the same tag
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// normalizeTags normalizes the tags and drops empty and duplicate entries
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		name := normalizeTag(tag)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func toSavingResponse(savingModel *models.Saving, userModel *models.User, currencyModel *models.Currency, categoryModel *models.Category, tags []string, collected float64) *dtos.SavingResponse {
+	response := &dtos.SavingResponse{
+		UUID:           savingModel.UUID,
+		User:           ToUserResponse(userModel),
+		Name:           savingModel.Name,
+		TargetAmount:   savingModel.TargetAmount,
+		CurrencyCode:   savingModel.CurrencyCode,
+		CurrencyFlag:   currencyModel.CountryFlag,
+		Image:          savingModel.Image,
+		FillingPlan:    savingModel.FillingPlan,
+		FillingNominal: savingModel.FillingNominal,
+		Priority:       savingModel.Priority,
+		Deadline:       savingModel.Deadline,
+		Collected:      collected,
+		Tags:           tags,
+		CreatedAt:      *savingModel.CreatedAt,
+		UpdatedAt:      *savingModel.UpdatedAt,
+	}
+
+	if response.Tags == nil {
+		response.Tags = []string{}
+	}
+
+	if categoryModel != nil {
+		response.Category = toCategoryResponse(categoryModel)
+	}
+
+	return response
+}
+
 func NewSavingRepository(db *gorm.DB) SavingRepository {
 	return &savingRepositoryImpl{db: db}
 }
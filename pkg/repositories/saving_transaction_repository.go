@@ -0,0 +1,317 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/models"
+)
+
+const (
+	TransactionTypeDeposit    = "deposit"
+	TransactionTypeWithdrawal = "withdrawal"
+
+	TransactionSourceManual     = "manual"
+	TransactionSourceAllocation = "allocation"
+	TransactionSourceRule       = "rule"
+)
+
+var (
+	ErrNoAllocationCandidates = errors.New("no unfinished savings found for this currency")
+	ErrInsufficientBalance    = errors.New("insufficient saving balance")
+)
+
+// AllocationPlanner fills the Amount of each candidate item based on the total amount to spread
+type AllocationPlanner func(amount float64, items []dtos.AllocationItem) []dtos.AllocationItem
+
+type SavingTransactionRepository interface {
+	CreateTransaction(req *dtos.SavingTransactionRequest) (*dtos.SavingTransactionResponse, error)
+	GetTransactions(userUuid string, savingUuid string) ([]*dtos.SavingTransactionResponse, error)
+	Allocate(req *dtos.AllocationRequest, plan AllocationPlanner) (*dtos.AllocationResponse, error)
+}
+
+type savingTransactionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// CreateTransaction implements SavingTransactionRepository.
+func (r *savingTransactionRepositoryImpl) CreateTransaction(req *dtos.SavingTransactionRequest) (*dtos.SavingTransactionResponse, error) {
+	transaction := models.SavingTransaction{
+		SavingUUID: req.SavingUUID,
+		UserUUID:   req.UserUUID,
+		Type:       req.Type,
+		Amount:     req.Amount,
+		Source:     TransactionSourceManual,
+	}
+	if req.Note != "" {
+		transaction.Note = &req.Note
+	}
+
+	var events []models.MilestoneEvent
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Kunci tabungan agar penarikan tidak membuat saldo minus
+		var savingModel models.Saving
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&savingModel, "uuid = ? AND user_uuid = ?", req.SavingUUID, req.UserUUID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrSavingNotFound
+			}
+			return err
+		}
+
+		if req.Type == TransactionTypeWithdrawal {
+			balances, err := savingBalances(tx, []string{savingModel.UUID})
+			if err != nil {
+				return err
+			}
+			if balances[savingModel.UUID]-req.Amount < -0.005 {
+				return ErrInsufficientBalance
+			}
+		}
+
+		var err error
+		events, err = postTransaction(tx, &transaction)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toSavingTransactionResponse(&transaction)
+	for i := range events {
+		response.Milestones = append(response.Milestones, *toMilestoneEventResponse(&events[i]))
+	}
+
+	return response, nil
+}
+
+// GetTransactions implements SavingTransactionRepository.
+func (r *savingTransactionRepositoryImpl) GetTransactions(userUuid string, savingUuid string) ([]*dtos.SavingTransactionResponse, error) {
+	var count int64
+	if err := r.db.Model(&models.Saving{}).Where("uuid = ? AND user_uuid = ?", savingUuid, userUuid).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, ErrSavingNotFound
+	}
+
+	var transactions []models.SavingTransaction
+	if err := r.db.Where("saving_uuid = ?", savingUuid).Order("created_at DESC").Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]*dtos.SavingTransactionResponse, 0, len(transactions))
+	for i := range transactions {
+		response = append(response, toSavingTransactionResponse(&transactions[i]))
+	}
+
+	return response, nil
+}
+
+// Allocate implements SavingTransactionRepository.
+func (r *savingTransactionRepositoryImpl) Allocate(req *dtos.AllocationRequest, plan AllocationPlanner) (*dtos.AllocationResponse, error) {
+	response := &dtos.AllocationResponse{
+		Strategy: req.Strategy,
+		Preview:  req.Preview,
+		Amount:   req.Amount,
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Kunci tabungan agar saldo tidak berubah selama alokasi
+		var savingModels []models.Saving
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_uuid = ? AND currency_code = ? AND is_completed IS NOT TRUE", req.UserUUID, req.CurrencyCode).
+			Find(&savingModels).Error; err != nil {
+			return err
+		}
+
+		savingUuids := make([]string, 0, len(savingModels))
+		for _, savingModel := range savingModels {
+			savingUuids = append(savingUuids, savingModel.UUID)
+		}
+
+		balances, err := savingBalances(tx, savingUuids)
+		if err != nil {
+			return err
+		}
+
+		items := make([]dtos.AllocationItem, 0, len(savingModels))
+		for _, savingModel := range savingModels {
+			remaining := savingModel.TargetAmount - balances[savingModel.UUID]
+			if remaining <= 0 {
+				continue
+			}
+
+			item := dtos.AllocationItem{
+				SavingUUID: savingModel.UUID,
+				Name:       savingModel.Name,
+				Priority:   savingModel.Priority,
+				Deadline:   savingModel.Deadline,
+				Remaining:  remaining,
+			}
+			if savingModel.CreatedAt != nil {
+				item.CreatedAt = *savingModel.CreatedAt
+			}
+			items = append(items, item)
+		}
+
+		if len(items) == 0 {
+			return ErrNoAllocationCandidates
+		}
+
+		response.Items = plan(req.Amount, items)
+		for _, item := range response.Items {
+			response.Allocated += item.Amount
+		}
+		response.Unallocated = req.Amount - response.Allocated
+
+		if req.Preview {
+			return nil
+		}
+
+		referenceUuid := uuid.New().String()
+		response.ReferenceUUID = referenceUuid
+
+		for i, item := range response.Items {
+			if item.Amount <= 0 {
+				continue
+			}
+
+			transaction := models.SavingTransaction{
+				SavingUUID:    item.SavingUUID,
+				UserUUID:      req.UserUUID,
+				Type:          TransactionTypeDeposit,
+				Amount:        item.Amount,
+				Source:        TransactionSourceAllocation,
+				ReferenceUUID: &referenceUuid,
+			}
+			if _, err := postTransaction(tx, &transaction); err != nil {
+				return err
+			}
+			response.Items[i].TransactionUUID = transaction.UUID
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// postTransaction writes a ledger transaction, keeps the completion flag of the saving in sync
+// and records the milestones crossed by a deposit. Every write to the ledger should go through here.
+func postTransaction(tx *gorm.DB, transaction *models.SavingTransaction) ([]models.MilestoneEvent, error) {
+	if err := tx.Create(transaction).Error; err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", transaction.Type, err)
+	}
+
+	var savingModel models.Saving
+	if err := tx.First(&savingModel, "uuid = ?", transaction.SavingUUID).Error; err != nil {
+		return nil, err
+	}
+
+	balances, err := savingBalances(tx, []string{savingModel.UUID})
+	if err != nil {
+		return nil, err
+	}
+
+	after := balances[savingModel.UUID]
+	before := after + transaction.Amount
+	if transaction.Type == TransactionTypeDeposit {
+		before = after - transaction.Amount
+	}
+
+	// Anggap lunas jika sisa kurang dari setengah sen
+	isCompleted := savingModel.IsCompleted != nil && *savingModel.IsCompleted
+	reachesTarget := savingModel.TargetAmount-after < 0.005
+	if isCompleted != reachesTarget {
+		if err := setSavingCompleted(tx, savingModel.UUID, reachesTarget); err != nil {
+			return nil, err
+		}
+	}
+
+	if transaction.Type != TransactionTypeDeposit {
+		return nil, nil
+	}
+
+	return recordMilestones(tx, &savingModel, before, after, transaction)
+}
+
+// savingBalances returns the current balance of each saving, keyed by saving uuid
+func savingBalances(db *gorm.DB, savingUuids []string) (map[string]float64, error) {
+	result := make(map[string]float64)
+	if len(savingUuids) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		SavingUUID string
+		Balance    float64
+	}
+
+	err := db.Model(&models.SavingTransaction{}).
+		Select("saving_uuid, COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE -amount END), 0) AS balance", TransactionTypeDeposit).
+		Where("saving_uuid IN ?", savingUuids).
+		Group("saving_uuid").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.SavingUUID] = row.Balance
+	}
+
+	return result, nil
+}
+
+// setSavingCompleted flags the saving as completed, or reopens it after a withdrawal
+func setSavingCompleted(tx *gorm.DB, savingUuid string, completed bool) error {
+	var completedAt *time.Time
+	if completed {
+		now := time.Now()
+		completedAt = &now
+	}
+
+	err := tx.Model(&models.Saving{}).
+		Where("uuid = ?", savingUuid).
+		Updates(map[string]interface{}{"is_completed": completed, "completed_at": completedAt}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update saving completion: %w", err)
+	}
+
+	return nil
+}
+
+func toSavingTransactionResponse(transaction *models.SavingTransaction) *dtos.SavingTransactionResponse {
+	response := &dtos.SavingTransactionResponse{
+		UUID:       transaction.UUID,
+		SavingUUID: transaction.SavingUUID,
+		Type:       transaction.Type,
+		Amount:     transaction.Amount,
+		Source:     transaction.Source,
+	}
+	if transaction.ReferenceUUID != nil {
+		response.ReferenceUUID = *transaction.ReferenceUUID
+	}
+	if transaction.Note != nil {
+		response.Note = *transaction.Note
+	}
+	if transaction.CreatedAt != nil {
+		response.CreatedAt = *transaction.CreatedAt
+	}
+
+	return response
+}
+
+func NewSavingTransactionRepository(db *gorm.DB) SavingTransactionRepository {
+	return &savingTransactionRepositoryImpl{db: db}
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"alfredo/tabunganku/pkg/models"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+type SessionRepository interface {
+	CreateSession(session *models.UserSession) error
+	GetSessions(userUuid string) ([]models.UserSession, error)
+	FindSession(userUuid string, uuid string) (*models.UserSession, error)
+	TouchSession(tokens string, lastSeenAt time.Time) error
+	RevokeSession(tokens string) error
+	RevokeAllSessions(userUuid string) error
+}
+
+type sessionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// CreateSession implements SessionRepository.
+func (s *sessionRepositoryImpl) CreateSession(session *models.UserSession) error {
+	if err := s.db.Create(session).Error; err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// GetSessions implements SessionRepository.
+func (s *sessionRepositoryImpl) GetSessions(userUuid string) ([]models.UserSession, error) {
+	var sessions []models.UserSession
+	if err := s.db.Where("user_uuid = ? AND revoked_at IS NULL", userUuid).Order("created_at DESC").Find(&sessions).Error; err != nil {
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return sessions, nil
+}
+
+// FindSession implements SessionRepository.
+func (s *sessionRepositoryImpl) FindSession(userUuid string, uuid string) (*models.UserSession, error) {
+	var session models.UserSession
+	if err := s.db.Where("uuid = ? AND user_uuid = ? AND revoked_at IS NULL", uuid, userUuid).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("please try again later")
+	}
+
+	return &session, nil
+}
+
+// TouchSession implements SessionRepository.
+func (s *sessionRepositoryImpl) TouchSession(tokens string, lastSeenAt time.Time) error {
+	return s.db.Model(&models.UserSession{}).
+		Where("tokens = ? AND revoked_at IS NULL", tokens).
+		Updates(map[string]interface{}{"last_seen_at": lastSeenAt, "updated_at": time.Now()}).Error
+}
+
+// RevokeSession implements SessionRepository.
+func (s *sessionRepositoryImpl) RevokeSession(tokens string) error {
+	now := time.Now()
+	err := s.db.Model(&models.UserSession{}).
+		Where("tokens = ? AND revoked_at IS NULL", tokens).
+		Updates(map[string]interface{}{"revoked_at": now, "updated_at": now}).Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAllSessions implements SessionRepository.
+func (s *sessionRepositoryImpl) RevokeAllSessions(userUuid string) error {
+	now := time.Now()
+	err := s.db.Model(&models.UserSession{}).
+		Where("user_uuid = ? AND revoked_at IS NULL", userUuid).
+		Updates(map[string]interface{}{"revoked_at": now, "updated_at": now}).Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepositoryImpl{db: db}
+}
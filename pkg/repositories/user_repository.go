@@ -3,6 +3,8 @@ package repositories
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -11,11 +13,24 @@ import (
 	"alfredo/tabunganku/pkg/models"
 )
 
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrEmailTaken       = errors.New("email already exists")
+	ErrPhoneNumberTaken = errors.New("phone number already used by another account")
+)
+
 type UserRepository interface {
 	Login(email string, password string) (user *models.User, err error)
-	Register(req *dtos.RegisterRequest) error
+	Register(req *dtos.RegisterRequest) (*models.User, error)
 	FindUserByUuid(uuid string) (*models.User, error)
 	FindUserByEmail(email string) (*models.User, error)
+	MarkPhoneVerified(uuid string) error
+	SearchUsers(filter *dtos.AdminUserFilter) ([]models.User, int64, error)
+	SetUserDisabled(uuid string, disabled bool) error
+	UpdatePassword(uuid string, hashedPassword string) error
+	UpdateProfile(uuid string, req *dtos.UpdateProfileRequest) (*models.User, error)
+	IsEmailTaken(email string) (bool, error)
+	GetPasswordPepperIds() ([]string, error)
 }
 
 type userRepositoryImpl struct {
@@ -27,7 +42,7 @@ func (u *userRepositoryImpl) FindUserByUuid(uuid string) (*models.User, error) {
 	var user models.User
 	if err := u.db.Where("uuid = ? AND deleted_at IS NULL", uuid).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("user not found")
+			return nil, ErrUserNotFound
 		} else {
 			return nil, fmt.Errorf("please try again later")
 		}
@@ -41,7 +56,7 @@ func (u *userRepositoryImpl) FindUserByEmail(email string) (*models.User, error)
 	var user models.User
 	if err := u.db.Where("email = ? AND deleted_at IS NULL", email).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("user not found")
+			return nil, ErrUserNotFound
 		} else {
 			return nil, fmt.Errorf("please try again later")
 		}
@@ -50,6 +65,143 @@ func (u *userRepositoryImpl) FindUserByEmail(email string) (*models.User, error)
 	return &user, nil
 }
 
+// MarkPhoneVerified implements UserRepository.
+func (u *userRepositoryImpl) MarkPhoneVerified(uuid string) error {
+	now := time.Now()
+	if err := u.db.Model(&models.User{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]interface{}{"phone_verified_at": now, "updated_at": now}).Error; err != nil {
+		return fmt.Errorf("failed to verify phone number: %w", err)
+	}
+
+	return nil
+}
+
+// SearchUsers implements UserRepository.
+func (u *userRepositoryImpl) SearchUsers(filter *dtos.AdminUserFilter) ([]models.User, int64, error) {
+	query := u.db.Model(&models.User{})
+	if filter.Query != "" {
+		like := "%" + strings.ToLower(filter.Query) + "%"
+		query = query.Where("LOWER(name) LIKE ? OR LOWER(email) LIKE ? OR phone_number LIKE ?", like, like, like)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("please try again later")
+	}
+
+	var users []models.User
+	if err := query.Order("created_at DESC").
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Find(&users).Error; err != nil {
+		return nil, 0, fmt.Errorf("please try again later")
+	}
+
+	return users, total, nil
+}
+
+// SetUserDisabled implements UserRepository.
+func (u *userRepositoryImpl) SetUserDisabled(uuid string, disabled bool) error {
+	var disabledAt *time.Time
+	if disabled {
+		now := time.Now()
+		disabledAt = &now
+	}
+
+	result := u.db.Model(&models.User{}).Where("uuid = ?", uuid).
+		Updates(map[string]interface{}{"disabled_at": disabledAt, "updated_at": time.Now()})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update user: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UpdatePassword implements UserRepository.
+func (u *userRepositoryImpl) UpdatePassword(uuid string, hashedPassword string) error {
+	if err := u.db.Model(&models.User{}).Where("uuid = ?", uuid).
+		Updates(map[string]interface{}{"password": hashedPassword, "updated_at": time.Now()}).Error; err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateProfile implements UserRepository.
+// Only the fields that are set in the request are changed. A new phone number has to be verified again.
+func (u *userRepositoryImpl) UpdateProfile(uuid string, req *dtos.UpdateProfileRequest) (*models.User, error) {
+	var user models.User
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("uuid = ? AND deleted_at IS NULL", uuid).First(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("please try again later")
+		}
+
+		updates := map[string]interface{}{"updated_at": time.Now()}
+		if req.Name != nil {
+			updates["name"] = *req.Name
+		}
+		if req.Photo != nil {
+			updates["photo"] = *req.Photo
+		}
+		if req.PhoneNumber != nil && (user.PhoneNumber == nil || *user.PhoneNumber != *req.PhoneNumber) {
+			var count int64
+			if err := tx.Unscoped().Model(&models.User{}).
+				Where("phone_number = ? AND uuid <> ?", *req.PhoneNumber, uuid).
+				Count(&count).Error; err != nil {
+				return fmt.Errorf("please try again later")
+			}
+			if count > 0 {
+				return ErrPhoneNumberTaken
+			}
+
+			updates["phone_number"] = *req.PhoneNumber
+			updates["phone_verified_at"] = nil
+		}
+
+		if err := tx.Model(&user).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update profile: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.FindUserByUuid(uuid)
+}
+
+// IsEmailTaken implements UserRepository.
+// Deleted accounts still hold their email, the same as in Register.
+func (u *userRepositoryImpl) IsEmailTaken(email string) (bool, error) {
+	var count int64
+	if err := u.db.Unscoped().Model(&models.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("please try again later")
+	}
+
+	return count > 0, nil
+}
+
+// GetPasswordPepperIds implements UserRepository.
+// Returns the pepper ids used by stored password hashes, read from the pepper=<id> hash parameter.
+func (u *userRepositoryImpl) GetPasswordPepperIds() ([]string, error) {
+	var ids []string
+	if err := u.db.Unscoped().Model(&models.User{}).
+		Where("password LIKE ?", "%pepper=%").
+		Distinct().Pluck("substring(password from 'pepper=([^$,]+)')", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to read password peppers: %w", err)
+	}
+
+	return ids, nil
+}
+
 // Login implements UserRepository.
 func (u *userRepositoryImpl) Login(email string, password string) (user *models.User, err error) {
 	if err := u.db.Where("email = ? AND password = ? AND deleted_at IS NULL", email, password).
@@ -66,41 +218,69 @@ func (u *userRepositoryImpl) Login(email string, password string) (user *models.
 }
 
 // Register implements UserRepository.
-func (u *userRepositoryImpl) Register(req *dtos.RegisterRequest) error {
+func (u *userRepositoryImpl) Register(req *dtos.RegisterRequest) (*models.User, error) {
 	var existingUser models.User
 	if err := u.db.Debug().Unscoped().Where("email = ? ", req.Email).First(&existingUser).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			// Email tidak ditemukan, boleh lanjut register
 			// Lanjut ke proses create user di bawah
 		} else {
-			return fmt.Errorf("please try again later")
+			return nil, fmt.Errorf("please try again later")
 		}
 	} else {
 		// Email ditemukan, berarti sudah ada
-		return fmt.Errorf("email already exists")
+		return nil, ErrEmailTaken
 	}
 
 	// Hash password with argon2
 	hashedPassword, err := helpers.HashPassword(req.Password)
 	if err != nil {
-		return fmt.Errorf("failed to hash password: %w", err)
+		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	return u.db.Transaction(func(tx *gorm.DB) error {
-		user := models.User{
-			Name:        req.Name,
-			Email:       req.Email,
-			Password:    hashedPassword,
-			PhoneNumber: &req.PhoneNumber,
-			Photo:       &req.Image,
-		}
+	user := models.User{
+		Name:        req.Name,
+		Email:       req.Email,
+		Password:    hashedPassword,
+		PhoneNumber: &req.PhoneNumber,
+		Photo:       &req.Image,
+	}
 
+	err = u.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(&user).Error; err != nil {
 			return fmt.Errorf("failed to create user: %w", err)
 		}
 
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// ToUserResponse builds the public representation of a user, optional columns become empty strings
+func ToUserResponse(user *models.User) dtos.UserResponse {
+	response := dtos.UserResponse{
+		UUID:          user.UUID,
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		PhoneVerified: user.PhoneVerifiedAt != nil,
+		MfaEnabled:    user.TotpEnabledAt != nil,
+		CreatedAt:     user.CreatedAt,
+
+		DeletionScheduledAt: user.DeletionScheduledAt,
+	}
+	if user.PhoneNumber != nil {
+		response.PhoneNumber = *user.PhoneNumber
+	}
+	if user.Photo != nil {
+		response.Image = *user.Photo
+	}
+
+	return response
 }
 
 func NewUserRepository(db *gorm.DB) UserRepository {
@@ -28,6 +28,13 @@ func InitializeRouterV1(server *config.Application) {
 		Layout:       "BaseLayout",
 		DocExpansion: "none",
 	}))
+
+	wellKnown := server.App.Group("/.well-known")
+	{
+		jwksController := injectors.InitializeJwksController()
+		jwksController.Router(wellKnown)
+	}
+
 	api := server.App.Group("/api")
 	{
 		v1 := api.Group("/v1")
@@ -50,6 +57,12 @@ func InitializeRouterV1(server *config.Application) {
 			{
 				authController := injectors.InitializeUserController()
 				authController.Router(auth)
+
+				mfaController := injectors.InitializeMfaController()
+				mfaController.Router(auth.Group("/mfa"))
+
+				oidcController := injectors.InitializeOidcController()
+				oidcController.Router(auth.Group("/oidc"))
 			}
 
 			saving := v1.Group("/savings")
@@ -58,6 +71,51 @@ func InitializeRouterV1(server *config.Application) {
 				savingController.Router(saving)
 			}
 
+			category := v1.Group("/categories")
+			{
+				categoryController := injectors.InitializeCategoryController()
+				categoryController.Router(category)
+			}
+
+			rule := v1.Group("/rules")
+			{
+				ruleController := injectors.InitializeRuleController()
+				ruleController.Router(rule)
+			}
+
+			cashflow := v1.Group("/cashflows")
+			{
+				cashflowController := injectors.InitializeCashflowController()
+				cashflowController.Router(cashflow)
+			}
+
+			me := v1.Group("/me")
+			{
+				profileController := injectors.InitializeProfileController()
+				profileController.Router(me)
+
+				accountController := injectors.InitializeAccountController()
+				accountController.Router(me)
+
+				badgeController := injectors.InitializeBadgeController()
+				badgeController.Router(me.Group("/badges"))
+
+				sessionController := injectors.InitializeSessionController()
+				sessionController.Router(me.Group("/sessions"))
+
+				apiKeyController := injectors.InitializeApiKeyController()
+				apiKeyController.Router(me.Group("/api-keys"))
+			}
+
+			admin := v1.Group("/admin")
+			{
+				adminController := injectors.InitializeAdminController()
+				adminController.Router(admin)
+
+				currencyController := injectors.InitializeCurrencyController()
+				currencyController.Router(admin.Group("/currencies"))
+			}
+
 		}
 
 	}
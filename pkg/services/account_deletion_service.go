@@ -0,0 +1,153 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"alfredo/tabunganku/config"
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+const (
+	defaultAccountDeletionGrace = 30
+	accountPurgeBatchSize       = 100
+)
+
+var (
+	ErrAccountDeletionScheduled    = errors.New("account deletion is already scheduled")
+	ErrAccountDeletionNotScheduled = errors.New("account deletion is not scheduled")
+)
+
+// AccountDeletionService deletes accounts after a grace period in which the user can still cancel
+type AccountDeletionService interface {
+	ScheduleDeletion(user *models.User, req *dtos.DeleteAccountRequest) (dtos.AccountDeletionResponse, error)
+	CancelDeletion(user *models.User) error
+	PurgeDueAccounts() (int, error)
+}
+
+type accountDeletionServiceImpl struct {
+	repo              repositories.AccountDeletionRepository
+	sessionRepository repositories.SessionRepository
+	apiKeyRepository  repositories.ApiKeyRepository
+	jwtService        JwtService
+	mailerService     MailerService
+	loginGuard        LoginGuardService
+	validator         *validator.CustomValidator
+}
+
+// ScheduleDeletion implements AccountDeletionService.
+// The user is logged out everywhere and every API key is revoked, logging in again before the date allows cancelling the deletion.
+func (a *accountDeletionServiceImpl) ScheduleDeletion(user *models.User, req *dtos.DeleteAccountRequest) (dtos.AccountDeletionResponse, error) {
+	if err := a.validator.Validate(req); err != nil {
+		return dtos.AccountDeletionResponse{}, err
+	}
+
+	if err := a.loginGuard.CheckCurrentPassword(user, req.Password); err != nil {
+		return dtos.AccountDeletionResponse{}, err
+	}
+
+	if user.DeletionScheduledAt != nil {
+		return dtos.AccountDeletionResponse{}, ErrAccountDeletionScheduled
+	}
+
+	deletionAt := time.Now().Add(accountDeletionGrace())
+	if err := a.repo.ScheduleDeletion(user.UUID, &deletionAt); err != nil {
+		return dtos.AccountDeletionResponse{}, err
+	}
+
+	if err := a.jwtService.RevokeAll(user.UUID); err != nil {
+		return dtos.AccountDeletionResponse{}, err
+	}
+	if err := a.sessionRepository.RevokeAllSessions(user.UUID); err != nil {
+		return dtos.AccountDeletionResponse{}, err
+	}
+	if err := a.apiKeyRepository.RevokeAllApiKeys(user.UUID); err != nil {
+		return dtos.AccountDeletionResponse{}, err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nYour Tabunganku account and all of its data will be deleted on %s.\n", user.Name, deletionAt.Format(time.RFC1123))
+	body += "\nChanged your mind? Login and cancel the deletion before that date.\n"
+	if err := a.mailerService.Send(user.Email, "Your Tabunganku account will be deleted", body); err != nil {
+		log.Println("Error while sending account deletion email", "error", err)
+	}
+
+	return dtos.AccountDeletionResponse{DeletionScheduledAt: deletionAt}, nil
+}
+
+// CancelDeletion implements AccountDeletionService.
+func (a *accountDeletionServiceImpl) CancelDeletion(user *models.User) error {
+	if user.DeletionScheduledAt == nil {
+		return ErrAccountDeletionNotScheduled
+	}
+
+	return a.repo.ScheduleDeletion(user.UUID, nil)
+}
+
+// PurgeDueAccounts implements AccountDeletionService.
+// It returns how many accounts were purged, one failing account does not stop the others.
+func (a *accountDeletionServiceImpl) PurgeDueAccounts() (int, error) {
+	now := time.Now()
+	users, err := a.repo.GetUsersToPurge(now, now.Add(-accountDeletionGrace()), accountPurgeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, user := range users {
+		files, err := a.repo.GetUserFiles(user.UUID)
+		if err != nil {
+			log.Println("Error while purging account", "user", user.UUID, "error", err)
+			continue
+		}
+
+		if err := a.repo.PurgeUser(user.UUID); err != nil {
+			log.Println("Error while purging account", "user", user.UUID, "error", err)
+			continue
+		}
+
+		// File baru dihapus setelah data di database berhasil dihapus
+		for _, file := range files {
+			removeStoredFile(file)
+		}
+		_ = a.jwtService.RevokeAll(user.UUID)
+		purged++
+	}
+
+	return purged, nil
+}
+
+// accountDeletionGrace returns the configured time between the deletion request and the purge
+func accountDeletionGrace() time.Duration {
+	days, err := strconv.Atoi(config.AccountDeletionGrace)
+	if err != nil || days <= 0 {
+		days = defaultAccountDeletionGrace
+	}
+
+	return time.Hour * 24 * time.Duration(days)
+}
+
+func NewAccountDeletionService(
+	repo repositories.AccountDeletionRepository,
+	sessionRepository repositories.SessionRepository,
+	apiKeyRepository repositories.ApiKeyRepository,
+	jwtService JwtService,
+	mailerService MailerService,
+	loginGuard LoginGuardService,
+	validator *validator.CustomValidator,
+) AccountDeletionService {
+	return &accountDeletionServiceImpl{
+		repo:              repo,
+		sessionRepository: sessionRepository,
+		apiKeyRepository:  apiKeyRepository,
+		jwtService:        jwtService,
+		mailerService:     mailerService,
+		loginGuard:        loginGuard,
+		validator:         validator,
+	}
+}
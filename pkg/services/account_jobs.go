@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const accountJobsInterval = time.Hour
+
+// AccountJobs runs the periodic account maintenance: purging deleted accounts and removing expired data exports.
+// It is started from main when isRunningCron is enabled.
+type AccountJobs interface {
+	Run(ctx context.Context)
+}
+
+type accountJobsImpl struct {
+	accountDeletionService AccountDeletionService
+	dataExportService      DataExportService
+}
+
+// Run implements AccountJobs, it blocks until ctx is cancelled
+func (a *accountJobsImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(accountJobsInterval)
+	defer ticker.Stop()
+
+	for {
+		a.runOnce()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *accountJobsImpl) runOnce() {
+	purged, err := a.accountDeletionService.PurgeDueAccounts()
+	if err != nil {
+		log.Println("Error while purging deleted accounts", "error", err)
+	} else if purged > 0 {
+		log.Println("Purged deleted accounts", "count", purged)
+	}
+
+	if err := a.dataExportService.CleanupExports(); err != nil {
+		log.Println("Error while cleaning up data exports", "error", err)
+	}
+}
+
+func NewAccountJobs(accountDeletionService AccountDeletionService, dataExportService DataExportService) AccountJobs {
+	return &accountJobsImpl{accountDeletionService: accountDeletionService, dataExportService: dataExportService}
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/helpers"
+	"alfredo/tabunganku/pkg/helpers/token"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+const (
+	defaultAdminPageLimit = 20
+	maxAdminPageLimit     = 100
+)
+
+var (
+	ErrCannotDisableSelf = errors.New("you can not disable your own account")
+	// Akun staff hanya boleh diubah oleh admin yang juga bisa mengatur role
+	ErrCannotManageStaff = errors.New("accounts with a role can only be managed by admins with the roles:manage permission")
+)
+
+// AdminService is the user management used by the admin API
+type AdminService interface {
+	SearchUsers(filter *dtos.AdminUserFilter) ([]dtos.AdminUserResponse, dtos.PaginationMeta, error)
+	GetUser(uuid string) (*dtos.AdminUserResponse, error)
+	DisableUser(adminUuid string, uuid string) error
+	EnableUser(adminUuid string, uuid string) error
+	ResetPassword(adminUuid string, uuid string) error
+	Impersonate(adminUuid string, uuid string, req *dtos.ImpersonateRequest) (dtos.ImpersonationResponse, error)
+	EndImpersonation(uuid string) error
+	GetImpersonations(filter *dtos.ImpersonationFilter) ([]dtos.ImpersonationAuditResponse, dtos.PaginationMeta, error)
+}
+
+type adminServiceImpl struct {
+	userRepository    repositories.UserRepository
+	roleRepository    repositories.RoleRepository
+	sessionRepository repositories.SessionRepository
+	jwtService        JwtService
+	passwordService   PasswordService
+
+	impersonationRepository repositories.ImpersonationRepository
+	validator               *validator.CustomValidator
+}
+
+// SearchUsers implements AdminService.
+func (a *adminServiceImpl) SearchUsers(filter *dtos.AdminUserFilter) ([]dtos.AdminUserResponse, dtos.PaginationMeta, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit < 1 {
+		filter.Limit = defaultAdminPageLimit
+	}
+	if filter.Limit > maxAdminPageLimit {
+		filter.Limit = maxAdminPageLimit
+	}
+
+	users, total, err := a.userRepository.SearchUsers(filter)
+	if err != nil {
+		return nil, dtos.PaginationMeta{}, err
+	}
+
+	responses := make([]dtos.AdminUserResponse, 0, len(users))
+	for i := range users {
+		responses = append(responses, toAdminUserResponse(&users[i], nil))
+	}
+
+	return responses, dtos.PaginationMeta{
+		Page:       filter.Page,
+		Limit:      filter.Limit,
+		Total:      int(total),
+		TotalPages: int(math.Ceil(float64(total) / float64(filter.Limit))),
+	}, nil
+}
+
+// GetUser implements AdminService.
+func (a *adminServiceImpl) GetUser(uuid string) (*dtos.AdminUserResponse, error) {
+	user, err := a.userRepository.FindUserByUuid(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := a.roleRepository.GetUserRoles(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toAdminUserResponse(user, roles)
+	return &response, nil
+}
+
+// DisableUser implements AdminService.
+// Every session is revoked so the user is logged out right away.
+func (a *adminServiceImpl) DisableUser(adminUuid string, uuid string) error {
+	if adminUuid == uuid {
+		return ErrCannotDisableSelf
+	}
+
+	if err := a.checkStaffTarget(adminUuid, uuid); err != nil {
+		return err
+	}
+
+	if err := a.userRepository.SetUserDisabled(uuid, true); err != nil {
+		return err
+	}
+
+	if err := a.jwtService.RevokeAll(uuid); err != nil {
+		return err
+	}
+
+	return a.sessionRepository.RevokeAllSessions(uuid)
+}
+
+// EnableUser implements AdminService.
+func (a *adminServiceImpl) EnableUser(adminUuid string, uuid string) error {
+	if err := a.checkStaffTarget(adminUuid, uuid); err != nil {
+		return err
+	}
+
+	return a.userRepository.SetUserDisabled(uuid, false)
+}
+
+// ResetPassword implements AdminService.
+// The current password stops working immediately and the user receives a reset link to choose a new one.
+func (a *adminServiceImpl) ResetPassword(adminUuid string, uuid string) error {
+	user, err := a.userRepository.FindUserByUuid(uuid)
+	if err != nil {
+		return err
+	}
+	if err := a.checkStaffTarget(adminUuid, user.UUID); err != nil {
+		return err
+	}
+
+	password := token.URLSafe(token.SessionEntropy)
+	hashedPassword, err := helpers.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	if err := a.userRepository.UpdatePassword(user.UUID, hashedPassword); err != nil {
+		return err
+	}
+
+	if err := a.jwtService.RevokeAll(user.UUID); err != nil {
+		return err
+	}
+	if err := a.sessionRepository.RevokeAllSessions(user.UUID); err != nil {
+		return err
+	}
+
+	// Langsung dikirim tanpa cooldown, password lama sudah tidak berlaku
+	return a.passwordService.SendResetEmail(user)
+}
+
+// checkStaffTarget only lets admins with the roles:manage permission change accounts that have a role
+func (a *adminServiceImpl) checkStaffTarget(adminUuid string, uuid string) error {
+	roles, err := a.roleRepository.GetUserRoles(uuid)
+	if err != nil {
+		return err
+	}
+	if len(roles) == 0 {
+		return nil
+	}
+
+	permissions, err := a.roleRepository.GetUserPermissions(adminUuid)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(permissions, PermissionRolesManage) {
+		return ErrCannotManageStaff
+	}
+
+	return nil
+}
+
+// Impersonate implements AdminService.
+// Every impersonation is written to the audit table before the token is handed out.
+func (a *adminServiceImpl) Impersonate(adminUuid string, uuid string, req *dtos.ImpersonateRequest) (dtos.ImpersonationResponse, error) {
+	if err := a.validator.Validate(req); err != nil {
+		return dtos.ImpersonationResponse{}, err
+	}
+
+	if adminUuid == uuid {
+		return dtos.ImpersonationResponse{}, ErrCannotImpersonateSelf
+	}
+
+	user, err := a.userRepository.FindUserByUuid(uuid)
+	if err != nil {
+		return dtos.ImpersonationResponse{}, err
+	}
+	if user.DisabledAt != nil {
+		return dtos.ImpersonationResponse{}, ErrAccountDisabled
+	}
+
+	roles, err := a.roleRepository.GetUserRoles(uuid)
+	if err != nil {
+		return dtos.ImpersonationResponse{}, err
+	}
+	if len(roles) > 0 {
+		return dtos.ImpersonationResponse{}, ErrCannotImpersonateStaff
+	}
+
+	token, claims, err := a.jwtService.GenerateImpersonationToken(adminUuid, user.UUID)
+	if err != nil {
+		return dtos.ImpersonationResponse{}, err
+	}
+
+	audit := models.ImpersonationAudit{
+		AdminUUID: adminUuid,
+		UserUUID:  user.UUID,
+		TokenID:   claims.ID,
+		Reason:    req.Reason,
+		IPAddress: optionalString(req.IPAddress),
+		UserAgent: optionalString(truncate(req.UserAgent, 255)),
+		ExpiresAt: claims.ExpiresAt.Time,
+	}
+	if err := a.impersonationRepository.CreateImpersonation(&audit); err != nil {
+		return dtos.ImpersonationResponse{}, err
+	}
+
+	return dtos.ImpersonationResponse{
+		UUID:        audit.UUID,
+		UserUuid:    user.UUID,
+		TokenType:   "Bearer",
+		AccessToken: token,
+		ExpiresIn:   impersonationTokenExpiry * 60,
+		ExpiresAt:   audit.ExpiresAt,
+	}, nil
+}
+
+// EndImpersonation implements AdminService.
+func (a *adminServiceImpl) EndImpersonation(uuid string) error {
+	audit, err := a.impersonationRepository.FindActiveImpersonation(uuid)
+	if err != nil {
+		return err
+	}
+
+	if err := a.jwtService.EndImpersonation(audit.TokenID, audit.ExpiresAt); err != nil {
+		return err
+	}
+
+	return a.impersonationRepository.EndImpersonation(audit.UUID, time.Now())
+}
+
+// GetImpersonations implements AdminService.
+func (a *adminServiceImpl) GetImpersonations(filter *dtos.ImpersonationFilter) ([]dtos.ImpersonationAuditResponse, dtos.PaginationMeta, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit < 1 {
+		filter.Limit = defaultAdminPageLimit
+	}
+	if filter.Limit > maxAdminPageLimit {
+		filter.Limit = maxAdminPageLimit
+	}
+
+	audits, total, err := a.impersonationRepository.GetImpersonations(filter)
+	if err != nil {
+		return nil, dtos.PaginationMeta{}, err
+	}
+
+	responses := make([]dtos.ImpersonationAuditResponse, 0, len(audits))
+	for _, audit := range audits {
+		responses = append(responses, dtos.ImpersonationAuditResponse{
+			UUID:      audit.UUID,
+			AdminUuid: audit.AdminUUID,
+			UserUuid:  audit.UserUUID,
+			Reason:    audit.Reason,
+			IPAddress: audit.IPAddress,
+			UserAgent: audit.UserAgent,
+			ExpiresAt: audit.ExpiresAt,
+			EndedAt:   audit.EndedAt,
+			CreatedAt: audit.CreatedAt,
+		})
+	}
+
+	return responses, dtos.PaginationMeta{
+		Page:       filter.Page,
+		Limit:      filter.Limit,
+		Total:      int(total),
+		TotalPages: int(math.Ceil(float64(total) / float64(filter.Limit))),
+	}, nil
+}
+
+func toAdminUserResponse(user *models.User, roles []string) dtos.AdminUserResponse {
+	return dtos.AdminUserResponse{
+		UUID:            user.UUID,
+		Name:            user.Name,
+		Email:           user.Email,
+		PhoneNumber:     user.PhoneNumber,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		PhoneVerifiedAt: user.PhoneVerifiedAt,
+		MfaEnabled:      user.TotpEnabledAt != nil,
+		DisabledAt:      user.DisabledAt,
+		CreatedAt:       user.CreatedAt,
+		Roles:           roles,
+	}
+}
+
+func NewAdminService(
+	userRepository repositories.UserRepository,
+	roleRepository repositories.RoleRepository,
+	sessionRepository repositories.SessionRepository,
+	jwtService JwtService,
+	passwordService PasswordService,
+	impersonationRepository repositories.ImpersonationRepository,
+	validator *validator.CustomValidator,
+) AdminService {
+	return &adminServiceImpl{
+		userRepository:    userRepository,
+		roleRepository:    roleRepository,
+		sessionRepository: sessionRepository,
+		jwtService:        jwtService,
+		passwordService:   passwordService,
+
+		impersonationRepository: impersonationRepository,
+		validator:               validator,
+	}
+}
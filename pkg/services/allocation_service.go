@@ -0,0 +1,163 @@
+package services
+
+import (
+	"math"
+	"sort"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+const (
+	AllocationByPriority   = "priority"
+	AllocationProportional = "proportional"
+	AllocationByDeadline   = "deadline"
+
+	allocationCentMultiplier = 100
+)
+
+type AllocationService interface {
+	Allocate(req *dtos.AllocationRequest) (*dtos.AllocationResponse, error)
+}
+
+type allocationServiceImpl struct {
+	repo         repositories.SavingTransactionRepository
+	badgeService BadgeService
+	validator    *validator.CustomValidator
+}
+
+// Allocate implements AllocationService.
+func (a *allocationServiceImpl) Allocate(req *dtos.AllocationRequest) (*dtos.AllocationResponse, error) {
+	if err := a.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	var plan repositories.AllocationPlanner
+	switch req.Strategy {
+	case AllocationByPriority:
+		plan = planByPriority
+	case AllocationByDeadline:
+		plan = planByDeadline
+	default:
+		plan = planProportional
+	}
+
+	response, err := a.repo.Allocate(req, plan)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.Preview {
+		_, _ = a.badgeService.Evaluate(req.UserUUID, BadgeEventTransactionPosted)
+	}
+
+	return response, nil
+}
+
+// planByPriority fills the goals one by one, highest priority first
+func planByPriority(amount float64, items []dtos.AllocationItem) []dtos.AllocationItem {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Priority != items[j].Priority {
+			return items[i].Priority > items[j].Priority
+		}
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+
+	return fillSequentially(amount, items)
+}
+
+// planByDeadline fills the goals one by one, earliest deadline first.
+// Goals without a deadline come last, ordered by priority.
+func planByDeadline(amount float64, items []dtos.AllocationItem) []dtos.AllocationItem {
+	sort.SliceStable(items, func(i, j int) bool {
+		left, right := items[i].Deadline, items[j].Deadline
+		switch {
+		case left != nil && right == nil:
+			return true
+		case left == nil && right != nil:
+			return false
+		case left != nil && right != nil && !left.Equal(*right):
+			return left.Before(*right)
+		case items[i].Priority != items[j].Priority:
+			return items[i].Priority > items[j].Priority
+		}
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+
+	return fillSequentially(amount, items)
+}
+
+// planProportional spreads the amount relative to the remaining amount of each goal.
+// Cents lost to rounding go to the goals with the largest fractional share.
+func planProportional(amount float64, items []dtos.AllocationItem) []dtos.AllocationItem {
+	total := toCents(amount)
+
+	var totalRemaining int64
+	for _, item := range items {
+		totalRemaining += toCents(item.Remaining)
+	}
+
+	if total >= totalRemaining {
+		for i := range items {
+			items[i].Amount = items[i].Remaining
+		}
+		return items
+	}
+
+	shares := make([]int64, len(items))
+	fractions := make([]float64, len(items))
+	var allocated int64
+	for i, item := range items {
+		exact := float64(total) * float64(toCents(item.Remaining)) / float64(totalRemaining)
+		shares[i] = int64(math.Floor(exact))
+		fractions[i] = exact - float64(shares[i])
+		allocated += shares[i]
+	}
+
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return fractions[order[i]] > fractions[order[j]]
+	})
+
+	for _, i := range order {
+		if allocated >= total {
+			break
+		}
+		shares[i]++
+		allocated++
+	}
+
+	for i := range items {
+		items[i].Amount = fromCents(shares[i])
+	}
+
+	return items
+}
+
+// fillSequentially gives each goal its full remaining amount until the total runs out
+func fillSequentially(amount float64, items []dtos.AllocationItem) []dtos.AllocationItem {
+	left := toCents(amount)
+	for i := range items {
+		share := min(toCents(items[i].Remaining), left)
+		items[i].Amount = fromCents(share)
+		left -= share
+	}
+
+	return items
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * allocationCentMultiplier))
+}
+
+func fromCents(cents int64) float64 {
+	return float64(cents) / allocationCentMultiplier
+}
+
+func NewAllocationService(repo repositories.SavingTransactionRepository, badgeService BadgeService, validator *validator.CustomValidator) AllocationService {
+	return &allocationServiceImpl{repo: repo, badgeService: badgeService, validator: validator}
+}
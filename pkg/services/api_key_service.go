@@ -0,0 +1,160 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/helpers/token"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+const (
+	// Format key: tbk_<prefix>_<secret>, prefix disimpan apa adanya untuk mencari key-nya
+	apiKeyPrefix     = "tbk_"
+	apiKeyPrefixBits = 32
+	apiKeySecretBits = 192
+
+	// ApiKeyToken is the claims type set for requests authenticated with an API key
+	ApiKeyToken TokenType = "api_key"
+)
+
+// apiKeyScopes are the scopes an API key can be granted, account management stays with real logins
+var apiKeyScopes = []string{ScopeSavingsRead, ScopeSavingsWrite}
+
+var (
+	ErrApiKeyInvalid         = errors.New("invalid api key")
+	ErrApiKeyScopeNotAllowed = errors.New("scope is not allowed for api keys")
+	ErrApiKeyExpiryInPast    = errors.New("expires_at must be in the future")
+)
+
+type ApiKeyService interface {
+	CreateApiKey(req *dtos.CreateApiKeyRequest) (*dtos.CreateApiKeyResponse, error)
+	GetApiKeys(userUuid string) ([]dtos.ApiKeyResponse, error)
+	RevokeApiKey(userUuid string, uuid string) error
+	Authenticate(key string) (*TokenClaims, error)
+}
+
+type apiKeyServiceImpl struct {
+	repo      repositories.ApiKeyRepository
+	validator *validator.CustomValidator
+}
+
+// CreateApiKey implements ApiKeyService.
+func (a *apiKeyServiceImpl) CreateApiKey(req *dtos.CreateApiKeyRequest) (*dtos.CreateApiKeyResponse, error) {
+	if err := a.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	for _, scope := range req.Scopes {
+		if !slices.Contains(apiKeyScopes, scope) {
+			return nil, fmt.Errorf("%w: %s", ErrApiKeyScopeNotAllowed, scope)
+		}
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrApiKeyExpiryInPast
+	}
+
+	prefix := token.Hex(apiKeyPrefixBits)
+	secret := token.Hex(apiKeySecretBits)
+	key := apiKeyPrefix + prefix + "_" + secret
+
+	apiKey := models.ApiKey{
+		UserUUID:  req.UserUUID,
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   hashOneTimeToken(key),
+		Scopes:    strings.Join(slices.Compact(slices.Sorted(slices.Values(req.Scopes))), " "),
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := a.repo.CreateApiKey(&apiKey); err != nil {
+		return nil, err
+	}
+
+	return &dtos.CreateApiKeyResponse{
+		ApiKeyResponse: toApiKeyResponse(apiKey),
+		Key:            key,
+	}, nil
+}
+
+// GetApiKeys implements ApiKeyService.
+func (a *apiKeyServiceImpl) GetApiKeys(userUuid string) ([]dtos.ApiKeyResponse, error) {
+	apiKeys, err := a.repo.GetApiKeys(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dtos.ApiKeyResponse, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		responses = append(responses, toApiKeyResponse(apiKey))
+	}
+
+	return responses, nil
+}
+
+// RevokeApiKey implements ApiKeyService.
+func (a *apiKeyServiceImpl) RevokeApiKey(userUuid string, uuid string) error {
+	return a.repo.RevokeApiKey(userUuid, uuid)
+}
+
+// Authenticate implements ApiKeyService.
+// The returned claims carry the key scopes so jwt.RequireScope works the same as for tokens.
+func (a *apiKeyServiceImpl) Authenticate(key string) (*TokenClaims, error) {
+	rest, ok := strings.CutPrefix(key, apiKeyPrefix)
+	if !ok {
+		return nil, ErrApiKeyInvalid
+	}
+	prefix, _, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != apiKeyPrefixBits/4 {
+		return nil, ErrApiKeyInvalid
+	}
+
+	apiKey, err := a.repo.FindApiKeyByPrefix(prefix)
+	if err != nil {
+		if errors.Is(err, repositories.ErrApiKeyNotFound) {
+			return nil, ErrApiKeyInvalid
+		}
+		return nil, err
+	}
+
+	if !token.Equal(hashOneTimeToken(key), apiKey.KeyHash) {
+		return nil, ErrApiKeyInvalid
+	}
+	if apiKey.ExpiresAt != nil && apiKey.ExpiresAt.Before(time.Now()) {
+		return nil, ErrApiKeyInvalid
+	}
+
+	// Last used hanya informasi, request tetap jalan walau gagal disimpan
+	_ = a.repo.TouchApiKey(apiKey.UUID, time.Now())
+
+	claims := &TokenClaims{
+		UserId: apiKey.UserUUID,
+		Type:   ApiKeyToken,
+		Scope:  strings.Fields(apiKey.Scopes),
+	}
+	claims.ID = apiKey.UUID
+
+	return claims, nil
+}
+
+func toApiKeyResponse(apiKey models.ApiKey) dtos.ApiKeyResponse {
+	return dtos.ApiKeyResponse{
+		UUID:       apiKey.UUID,
+		Name:       apiKey.Name,
+		Prefix:     apiKeyPrefix + apiKey.Prefix,
+		Scopes:     strings.Fields(apiKey.Scopes),
+		ExpiresAt:  apiKey.ExpiresAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
+
+func NewApiKeyService(repo repositories.ApiKeyRepository, validator *validator.CustomValidator) ApiKeyService {
+	return &apiKeyServiceImpl{repo: repo, validator: validator}
+}
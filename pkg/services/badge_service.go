@@ -0,0 +1,67 @@
+package services
+
+import (
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/repositories"
+)
+
+// Domain events that trigger badge evaluation
+const (
+	BadgeEventSavingCreated     = "saving_created"
+	BadgeEventTransactionPosted = "transaction_posted"
+)
+
+type BadgeService interface {
+	GetUserBadges(userUuid string) ([]*dtos.UserBadgeResponse, error)
+	Evaluate(userUuid string, event string) ([]*dtos.UserBadgeResponse, error)
+}
+
+type badgeServiceImpl struct {
+	repo repositories.BadgeRepository
+}
+
+// GetUserBadges implements BadgeService.
+func (b *badgeServiceImpl) GetUserBadges(userUuid string) ([]*dtos.UserBadgeResponse, error) {
+	return b.repo.GetUserBadges(userUuid)
+}
+
+// Evaluate implements BadgeService.
+// Badges are declared as rows (event, metric, threshold), so a new badge only needs a new row.
+func (b *badgeServiceImpl) Evaluate(userUuid string, event string) ([]*dtos.UserBadgeResponse, error) {
+	badges, err := b.repo.FindPendingBadges(userUuid, event)
+	if err != nil {
+		return nil, err
+	}
+
+	// Beberapa badge bisa memakai metrik yang sama, hitung sekali saja
+	metrics := make(map[string]float64)
+	awarded := make([]*dtos.UserBadgeResponse, 0)
+	for i := range badges {
+		value, ok := metrics[badges[i].Metric]
+		if !ok {
+			value, err = b.repo.Metric(userUuid, badges[i].Metric)
+			if err != nil {
+				return nil, err
+			}
+			metrics[badges[i].Metric] = value
+		}
+
+		if value < badges[i].Threshold {
+			continue
+		}
+
+		badge, err := b.repo.AwardBadge(userUuid, &badges[i])
+		if err != nil {
+			return nil, err
+		}
+		if badge != nil {
+			awarded = append(awarded, badge)
+		}
+	}
+
+	return awarded, nil
+}
+
+func NewBadgeService(repo repositories.BadgeRepository) BadgeService {
+	return &badgeServiceImpl{repo: repo}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+type CategoryService interface {
+	CreateCategory(req *dtos.CategoryRequest) (*dtos.CategoryResponse, error)
+	GetCategories(userUuid string) ([]*dtos.CategoryResponse, error)
+	GetCategory(userUuid string, uuid string) (*dtos.CategoryResponse, error)
+	UpdateCategory(uuid string, req *dtos.CategoryRequest) (*dtos.CategoryResponse, error)
+	DeleteCategory(userUuid string, uuid string) error
+}
+
+type categoryServiceImpl struct {
+	repo      repositories.CategoryRepository
+	validator *validator.CustomValidator
+}
+
+// CreateCategory implements CategoryService.
+func (c *categoryServiceImpl) CreateCategory(req *dtos.CategoryRequest) (*dtos.CategoryResponse, error) {
+	if err := c.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	return c.repo.CreateCategory(req)
+}
+
+// GetCategories implements CategoryService.
+func (c *categoryServiceImpl) GetCategories(userUuid string) ([]*dtos.CategoryResponse, error) {
+	return c.repo.GetCategories(userUuid)
+}
+
+// GetCategory implements CategoryService.
+func (c *categoryServiceImpl) GetCategory(userUuid string, uuid string) (*dtos.CategoryResponse, error) {
+	return c.repo.FindCategory(userUuid, uuid)
+}
+
+// UpdateCategory implements CategoryService.
+func (c *categoryServiceImpl) UpdateCategory(uuid string, req *dtos.CategoryRequest) (*dtos.CategoryResponse, error) {
+	if err := c.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	return c.repo.UpdateCategory(uuid, req)
+}
+
+// DeleteCategory implements CategoryService.
+func (c *categoryServiceImpl) DeleteCategory(userUuid string, uuid string) error {
+	return c.repo.DeleteCategory(userUuid, uuid)
+}
+
+func NewCategoryService(repo repositories.CategoryRepository, validator *validator.CustomValidator) CategoryService {
+	return &categoryServiceImpl{repo: repo, validator: validator}
+}
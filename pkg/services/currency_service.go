@@ -0,0 +1,51 @@
+package services
+
+import (
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+type CurrencyService interface {
+	GetCurrencies() ([]dtos.CurrencyResponse, error)
+	CreateCurrency(req *dtos.CurrencyRequest) (*dtos.CurrencyResponse, error)
+	UpdateCurrency(uuid string, req *dtos.CurrencyRequest) (*dtos.CurrencyResponse, error)
+	DeleteCurrency(uuid string) error
+}
+
+type currencyServiceImpl struct {
+	repo      repositories.CurrencyRepository
+	validator *validator.CustomValidator
+}
+
+// GetCurrencies implements CurrencyService.
+func (c *currencyServiceImpl) GetCurrencies() ([]dtos.CurrencyResponse, error) {
+	return c.repo.GetCurrencies()
+}
+
+// CreateCurrency implements CurrencyService.
+func (c *currencyServiceImpl) CreateCurrency(req *dtos.CurrencyRequest) (*dtos.CurrencyResponse, error) {
+	if err := c.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	return c.repo.CreateCurrency(req)
+}
+
+// UpdateCurrency implements CurrencyService.
+func (c *currencyServiceImpl) UpdateCurrency(uuid string, req *dtos.CurrencyRequest) (*dtos.CurrencyResponse, error) {
+	if err := c.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	return c.repo.UpdateCurrency(uuid, req)
+}
+
+// DeleteCurrency implements CurrencyService.
+func (c *currencyServiceImpl) DeleteCurrency(uuid string) error {
+	return c.repo.DeleteCurrency(uuid)
+}
+
+func NewCurrencyService(repo repositories.CurrencyRepository, validator *validator.CustomValidator) CurrencyService {
+	return &currencyServiceImpl{repo: repo, validator: validator}
+}
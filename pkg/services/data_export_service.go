@@ -0,0 +1,288 @@
+package services
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"alfredo/tabunganku/config"
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
+)
+
+const (
+	defaultDataExportPath   = "./storage/exports"
+	defaultDataExportExpire = 24 * 7
+	// Export yang belum selesai setelah selama ini dianggap gagal
+	dataExportTimeout = time.Hour
+
+	uploadsDir = "uploads"
+)
+
+var ErrDataExportNotReady = errors.New("data export is not ready yet")
+
+// DataExportService builds a zip of everything stored about a user in the background
+type DataExportService interface {
+	RequestExport(user *models.User) (dtos.DataExportResponse, error)
+	GetExport(userUuid string, uuid string) (dtos.DataExportResponse, error)
+	ExportFile(userUuid string, uuid string) (string, error)
+	CleanupExports() error
+}
+
+type dataExportServiceImpl struct {
+	repo           repositories.DataExportRepository
+	userRepository repositories.UserRepository
+	mailerService  MailerService
+}
+
+// RequestExport implements DataExportService.
+// The zip is built in a goroutine, the client polls GetExport until the status is completed.
+func (d *dataExportServiceImpl) RequestExport(user *models.User) (dtos.DataExportResponse, error) {
+	export, err := d.repo.CreateExport(user.UUID)
+	if err != nil {
+		return dtos.DataExportResponse{}, err
+	}
+
+	go d.build(export.UUID, user.UUID)
+
+	return toDataExportResponse(export), nil
+}
+
+// GetExport implements DataExportService.
+func (d *dataExportServiceImpl) GetExport(userUuid string, uuid string) (dtos.DataExportResponse, error) {
+	export, err := d.repo.FindExport(userUuid, uuid)
+	if err != nil {
+		return dtos.DataExportResponse{}, err
+	}
+
+	return toDataExportResponse(export), nil
+}
+
+// ExportFile implements DataExportService.
+func (d *dataExportServiceImpl) ExportFile(userUuid string, uuid string) (string, error) {
+	export, err := d.repo.FindExport(userUuid, uuid)
+	if err != nil {
+		return "", err
+	}
+
+	if export.ExpiresAt != nil && time.Now().After(*export.ExpiresAt) {
+		return "", repositories.ErrDataExportNotFound
+	}
+	if export.Status != repositories.DataExportCompleted || export.FilePath == nil {
+		return "", ErrDataExportNotReady
+	}
+
+	return *export.FilePath, nil
+}
+
+// CleanupExports implements DataExportService.
+// Expired zips are deleted and exports that were interrupted are marked as failed.
+func (d *dataExportServiceImpl) CleanupExports() error {
+	if err := d.repo.FailStaleExports(time.Now().Add(-dataExportTimeout)); err != nil {
+		return err
+	}
+
+	exports, err := d.repo.GetExpiredExports(time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, export := range exports {
+		if export.FilePath != nil {
+			removeStoredFile(*export.FilePath)
+		}
+		if err := d.repo.DeleteExport(export.UUID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// build writes the zip and records the result, it runs outside of the request
+func (d *dataExportServiceImpl) build(exportUuid string, userUuid string) {
+	if err := d.repo.MarkExportProcessing(exportUuid); err != nil {
+		log.Println("Error while starting data export", "error", err)
+	}
+
+	user, path, err := d.writeArchive(exportUuid, userUuid)
+	if err != nil {
+		log.Println("Error while building data export", "export", exportUuid, "error", err)
+		if err := d.repo.FailExport(exportUuid, err.Error()); err != nil {
+			log.Println("Error while updating data export", "error", err)
+		}
+		return
+	}
+
+	expiresAt := time.Now().Add(dataExportExpiry())
+	if err := d.repo.CompleteExport(exportUuid, path, expiresAt); err != nil {
+		log.Println("Error while updating data export", "error", err)
+		removeStoredFile(path)
+		return
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nThe export of your Tabunganku data is ready. Download it from the app before %s.\n", user.Name, expiresAt.Format(time.RFC1123))
+	if err := d.mailerService.Send(user.Email, "Your Tabunganku data export is ready", body); err != nil {
+		log.Println("Error while sending data export email", "error", err)
+	}
+}
+
+// writeArchive creates <export path>/<export uuid>.zip with the profile, savings, transactions and uploaded images
+func (d *dataExportServiceImpl) writeArchive(exportUuid string, userUuid string) (*models.User, string, error) {
+	user, err := d.userRepository.FindUserByUuid(userUuid)
+	if err != nil {
+		return nil, "", err
+	}
+	savings, err := d.repo.GetUserSavings(userUuid)
+	if err != nil {
+		return nil, "", err
+	}
+	transactions, err := d.repo.GetUserTransactions(userUuid)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dir := dataExportPath()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	path := filepath.Join(dir, exportUuid+".zip")
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	err = writeExportEntries(zip.NewWriter(file), user, savings, transactions)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		removeStoredFile(path)
+		return nil, "", err
+	}
+
+	return user, path, nil
+}
+
+func writeExportEntries(archive *zip.Writer, user *models.User, savings []models.Saving, transactions []models.SavingTransaction) error {
+	entries := map[string]interface{}{
+		"profile.json":      repositories.ToUserResponse(user),
+		"savings.json":      savings,
+		"transactions.json": transactions,
+	}
+	for name, data := range entries {
+		writer, err := archive.Create(name)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	images := make([]string, 0, len(savings)+1)
+	if user.Photo != nil {
+		images = append(images, *user.Photo)
+	}
+	for _, saving := range savings {
+		images = append(images, saving.Image)
+	}
+	for _, image := range images {
+		// Gambar yang bukan upload lokal (misalnya URL) sudah ada di savings.json
+		if !isLocalUpload(image) {
+			continue
+		}
+		if err := copyIntoArchive(archive, image, "images/"+filepath.Base(image)); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func copyIntoArchive(archive *zip.Writer, path string, name string) error {
+	source, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer source.Close()
+
+	writer, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := io.Copy(writer, source); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// isLocalUpload reports whether the path points to a file saved in ./uploads
+func isLocalUpload(path string) bool {
+	if path == "" {
+		return false
+	}
+
+	return strings.HasPrefix(filepath.Clean(path), uploadsDir+string(filepath.Separator))
+}
+
+// removeStoredFile deletes an uploaded image or export zip, other paths are left alone
+func removeStoredFile(path string) {
+	exportDir := filepath.Clean(dataExportPath()) + string(filepath.Separator)
+	if !isLocalUpload(path) && !strings.HasPrefix(filepath.Clean(path), exportDir) {
+		return
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Println("Error while removing file", "path", path, "error", err)
+	}
+}
+
+func dataExportPath() string {
+	if config.DataExportPath == "" {
+		return defaultDataExportPath
+	}
+
+	return config.DataExportPath
+}
+
+// dataExportExpiry returns how long a finished export can be downloaded
+func dataExportExpiry() time.Duration {
+	hours, err := strconv.Atoi(config.DataExportExpire)
+	if err != nil || hours <= 0 {
+		hours = defaultDataExportExpire
+	}
+
+	return time.Hour * time.Duration(hours)
+}
+
+func toDataExportResponse(export *models.DataExport) dtos.DataExportResponse {
+	return dtos.DataExportResponse{
+		UUID:        export.UUID,
+		Status:      export.Status,
+		Error:       export.Error,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+		CreatedAt:   export.CreatedAt,
+	}
+}
+
+func NewDataExportService(repo repositories.DataExportRepository, userRepository repositories.UserRepository, mailerService MailerService) DataExportService {
+	return &dataExportServiceImpl{repo: repo, userRepository: userRepository, mailerService: mailerService}
+}
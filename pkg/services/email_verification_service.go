@@ -0,0 +1,127 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"alfredo/tabunganku/config"
+	"alfredo/tabunganku/pkg/helpers/token"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
+)
+
+const (
+	defaultEmailVerificationExpiry = 24
+
+	// Batas kirim ulang email verifikasi
+	emailVerificationCooldown       = time.Minute
+	emailVerificationDailyLimit     = 5
+	emailVerificationCooldownPrefix = "email_verification_cooldown:"
+	emailVerificationDailyPrefix    = "email_verification_daily:"
+)
+
+var (
+	ErrEmailAlreadyVerified    = errors.New("email already verified")
+	ErrVerificationRateLimited = errors.New("too many verification emails, please try again later")
+	ErrEmailNotVerified        = errors.New("please verify your email address first")
+)
+
+type EmailVerificationService interface {
+	SendVerification(user *models.User) error
+	ResendVerification(user *models.User) error
+	VerifyEmail(token string) error
+}
+
+type emailVerificationServiceImpl struct {
+	repo          repositories.EmailVerificationRepository
+	redisService  RedisService
+	mailerService MailerService
+}
+
+// SendVerification implements EmailVerificationService.
+func (e *emailVerificationServiceImpl) SendVerification(user *models.User) error {
+	rawToken := token.URLSafe(token.LinkEntropy)
+	expiry := emailVerificationExpiry()
+	if err := e.repo.CreateVerificationToken(user.UUID, hashOneTimeToken(rawToken), time.Now().Add(expiry)); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nUse this token to verify your Tabunganku email address: %s\n", user.Name, rawToken)
+	if config.EmailVerificationUrl != "" {
+		body += fmt.Sprintf("\nOr open this link: %s?token=%s\n", config.EmailVerificationUrl, url.QueryEscape(rawToken))
+	}
+	body += fmt.Sprintf("\nThe token expires in %d hours.\n", int(expiry.Hours()))
+
+	return e.mailerService.Send(user.Email, "Verify your Tabunganku email address", body)
+}
+
+// ResendVerification implements EmailVerificationService.
+func (e *emailVerificationServiceImpl) ResendVerification(user *models.User) error {
+	if user.EmailVerifiedAt != nil {
+		return ErrEmailAlreadyVerified
+	}
+
+	first, err := e.redisService.SetIfNotExists(emailVerificationCooldownPrefix+user.UUID, true, emailVerificationCooldown)
+	if err != nil {
+		return err
+	}
+	if !first {
+		return ErrVerificationRateLimited
+	}
+
+	dailyKey := emailVerificationDailyPrefix + user.UUID + ":" + time.Now().Format("2006-01-02")
+	count, err := e.redisService.Increment(dailyKey)
+	if err != nil {
+		return err
+	}
+	if count == 1 {
+		_ = e.redisService.Expire(dailyKey, time.Hour*24)
+	}
+	if count > emailVerificationDailyLimit {
+		return ErrVerificationRateLimited
+	}
+
+	return e.SendVerification(user)
+}
+
+// VerifyEmail implements EmailVerificationService.
+func (e *emailVerificationServiceImpl) VerifyEmail(token string) error {
+	if token == "" {
+		return repositories.ErrVerificationTokenInvalid
+	}
+
+	_, err := e.repo.ConsumeVerificationToken(hashOneTimeToken(token))
+	return err
+}
+
+// IsEmailVerificationOverdue reports whether an unverified account is past its grace period
+// and should be blocked from creating savings
+func IsEmailVerificationOverdue(user *models.User) bool {
+	if user.EmailVerifiedAt != nil {
+		return false
+	}
+
+	hours, _ := strconv.Atoi(config.EmailVerificationGrace)
+	if hours > 0 && user.CreatedAt != nil && time.Since(*user.CreatedAt) < time.Hour*time.Duration(hours) {
+		return false
+	}
+
+	return true
+}
+
+// emailVerificationExpiry returns the configured lifetime of a verification token
+func emailVerificationExpiry() time.Duration {
+	hours, err := strconv.Atoi(config.EmailVerificationExpire)
+	if err != nil || hours <= 0 {
+		hours = defaultEmailVerificationExpiry
+	}
+
+	return time.Hour * time.Duration(hours)
+}
+
+func NewEmailVerificationService(repo repositories.EmailVerificationRepository, redisService RedisService, mailerService MailerService) EmailVerificationService {
+	return &emailVerificationServiceImpl{repo: repo, redisService: redisService, mailerService: mailerService}
+}
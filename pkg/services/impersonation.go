@@ -0,0 +1,27 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	ErrImpersonationReadOnly = errors.New("impersonation is read-only")
+	ErrCannotImpersonateSelf = errors.New("you can not impersonate yourself")
+	// Akun staff tidak boleh di-impersonate supaya permission admin tidak bisa dipinjam
+	ErrCannotImpersonateStaff = errors.New("accounts with a role can not be impersonated")
+)
+
+// impersonationScopes limits impersonation tokens to reading, support staff only need to see what the user sees
+var impersonationScopes = []string{ScopeSavingsRead}
+
+// IsImpersonationMethodAllowed reports whether a request method can be used with an impersonation token
+func IsImpersonationMethodAllowed(method string) bool {
+	switch method {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+		return true
+	}
+
+	return false
+}
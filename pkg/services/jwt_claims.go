@@ -0,0 +1,81 @@
+package services
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"alfredo/tabunganku/config"
+)
+
+const (
+	defaultJwtIssuer   = "tabunganku"
+	defaultJwtAudience = "tabunganku-api"
+)
+
+// Scopes carried by tokens, checked per route with jwt.RequireScope
+const (
+	ScopeSavingsRead  = "savings:read"
+	ScopeSavingsWrite = "savings:write"
+	ScopeAccount      = "account"
+)
+
+// userScopes are granted to tokens issued from a regular login
+var userScopes = []string{ScopeSavingsRead, ScopeSavingsWrite, ScopeAccount}
+
+var ErrInvalidClaims = errors.New("invalid token claims")
+
+// TokenClaims is the payload of every token issued by JwtService
+type TokenClaims struct {
+	jwt.RegisteredClaims
+	UserId string `json:"user_id"`
+	// Tokens identifies the login (family) the token was issued from
+	Tokens     string    `json:"tokens,omitempty"`
+	Generation int64     `json:"gen"`
+	Type       TokenType `json:"type"`
+	Scope      []string  `json:"scope,omitempty"`
+	// Path binds a webview token to the single page it may open
+	Path string `json:"path,omitempty"`
+	// ImpersonatorId is the admin acting as the user, only set on impersonation tokens
+	ImpersonatorId string `json:"impersonator_id,omitempty"`
+}
+
+// Validate implements jwt.ClaimsValidator, it runs after the registered claims are checked
+func (c *TokenClaims) Validate() error {
+	if c.ID == "" || c.UserId == "" || c.Type == "" {
+		return ErrInvalidClaims
+	}
+	if c.Type == ImpersonationToken && c.ImpersonatorId == "" {
+		return ErrInvalidClaims
+	}
+
+	return nil
+}
+
+// HasScope reports whether the token was granted every given scope
+func (c *TokenClaims) HasScope(scopes ...string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scope, scope) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func jwtIssuer() string {
+	if config.JwtIssuer == "" {
+		return defaultJwtIssuer
+	}
+
+	return config.JwtIssuer
+}
+
+func jwtAudience() string {
+	if config.JwtAudience == "" {
+		return defaultJwtAudience
+	}
+
+	return config.JwtAudience
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"alfredo/tabunganku/config"
+	"alfredo/tabunganku/pkg/dtos"
+)
+
+const (
+	JwtAlgorithmRS256 = "RS256"
+	JwtAlgorithmEdDSA = "EdDSA"
+)
+
+// jwtKey is one configured key pair, the private key is nil for keys that only verify
+type jwtKey struct {
+	kid        string
+	method     jwt.SigningMethod
+	privateKey crypto.PrivateKey
+	publicKey  crypto.PublicKey
+}
+
+// jwtKeyring holds every key that can verify tokens and the one used to sign new tokens.
+// Without an active key tokens are signed with the HMAC secret.
+type jwtKeyring struct {
+	active *jwtKey
+	keys   map[string]*jwtKey
+}
+
+var (
+	keyringOnce sync.Once
+	keyring     *jwtKeyring
+	keyringErr  error
+)
+
+// LoadJwtKeys loads and validates the keys from config once. It is called at startup,
+// so a missing or mismatched key stops the boot instead of failing the first login.
+func LoadJwtKeys() error {
+	keyringOnce.Do(func() {
+		keyring, keyringErr = loadKeyring()
+		if keyringErr != nil {
+			keyringErr = fmt.Errorf("failed to load jwt keys: %w", keyringErr)
+		}
+	})
+
+	return keyringErr
+}
+
+// getKeyring returns the loaded keys, or the error that stopped them from loading
+func getKeyring() (*jwtKeyring, error) {
+	if err := LoadJwtKeys(); err != nil {
+		return nil, err
+	}
+
+	return keyring, nil
+}
+
+func loadKeyring() (*jwtKeyring, error) {
+	cfg, err := config.GetJwtKeysConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	ring := &jwtKeyring{keys: make(map[string]*jwtKey)}
+
+	for _, keyConfig := range cfg.Keys {
+		key, err := loadKey(keyConfig)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", keyConfig.Kid, err)
+		}
+		ring.keys[key.kid] = key
+	}
+
+	if cfg.ActiveKid != "" {
+		active, ok := ring.keys[cfg.ActiveKid]
+		if !ok {
+			return nil, fmt.Errorf("active key %q is not configured", cfg.ActiveKid)
+		}
+		if active.privateKey == nil {
+			return nil, fmt.Errorf("active key %q has no private key", cfg.ActiveKid)
+		}
+		ring.active = active
+	}
+
+	return ring, nil
+}
+
+func loadKey(keyConfig config.JwtKeyConfig) (*jwtKey, error) {
+	if keyConfig.Kid == "" {
+		return nil, fmt.Errorf("kid is required")
+	}
+
+	key := &jwtKey{kid: keyConfig.Kid}
+
+	var privatePem, publicPem []byte
+	var err error
+	if keyConfig.PrivateKey != "" {
+		if privatePem, err = os.ReadFile(keyConfig.PrivateKey); err != nil {
+			return nil, err
+		}
+	}
+	if keyConfig.PublicKey != "" {
+		if publicPem, err = os.ReadFile(keyConfig.PublicKey); err != nil {
+			return nil, err
+		}
+	}
+	if privatePem == nil && publicPem == nil {
+		return nil, fmt.Errorf("private_key or public_key is required")
+	}
+
+	switch keyConfig.Algorithm {
+	case JwtAlgorithmRS256:
+		key.method = jwt.SigningMethodRS256
+		if privatePem != nil {
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privatePem)
+			if err != nil {
+				return nil, err
+			}
+			key.privateKey = privateKey
+			key.publicKey = &privateKey.PublicKey
+		}
+		if publicPem != nil {
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicPem)
+			if err != nil {
+				return nil, err
+			}
+			if err := matchPublicKey(key.publicKey, publicKey); err != nil {
+				return nil, err
+			}
+			key.publicKey = publicKey
+		}
+	case JwtAlgorithmEdDSA:
+		key.method = jwt.SigningMethodEdDSA
+		if privatePem != nil {
+			privateKey, err := jwt.ParseEdPrivateKeyFromPEM(privatePem)
+			if err != nil {
+				return nil, err
+			}
+			key.privateKey = privateKey
+			key.publicKey = privateKey.(ed25519.PrivateKey).Public()
+		}
+		if publicPem != nil {
+			publicKey, err := jwt.ParseEdPublicKeyFromPEM(publicPem)
+			if err != nil {
+				return nil, err
+			}
+			if err := matchPublicKey(key.publicKey, publicKey); err != nil {
+				return nil, err
+			}
+			key.publicKey = publicKey
+		}
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", keyConfig.Algorithm)
+	}
+
+	return key, nil
+}
+
+// matchPublicKey checks that the configured public key belongs to the private key.
+// Derived is nil when only the public key is configured.
+func matchPublicKey(derived crypto.PublicKey, configured crypto.PublicKey) error {
+	if derived == nil {
+		return nil
+	}
+
+	equal, ok := derived.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !equal.Equal(configured) {
+		return errors.New("public_key does not match private_key")
+	}
+
+	return nil
+}
+
+// signingKey returns the method, key and kid used for new tokens
+func (k *jwtKeyring) signingKey() (jwt.SigningMethod, interface{}, string) {
+	if k.active == nil {
+		return jwt.SigningMethodHS256, []byte(config.JwtSecret), ""
+	}
+
+	return k.active.method, k.active.privateKey, k.active.kid
+}
+
+// verificationKey picks the key for a parsed token based on its kid header
+func (k *jwtKeyring) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		// Token lama tanpa kid ditandatangani dengan secret HMAC
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || config.JwtSecret == "" {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(config.JwtSecret), nil
+	}
+
+	key, ok := k.keys[kid]
+	if !ok || token.Method.Alg() != key.method.Alg() {
+		return nil, jwt.ErrSignatureInvalid
+	}
+
+	return key.publicKey, nil
+}
+
+// jwks returns the public keys in JSON Web Key Set format
+func (k *jwtKeyring) jwks() dtos.JwksResponse {
+	response := dtos.JwksResponse{Keys: make([]dtos.JwkResponse, 0, len(k.keys))}
+	for _, key := range k.keys {
+		jwk := dtos.JwkResponse{Kid: key.kid, Use: "sig", Alg: key.method.Alg()}
+		switch publicKey := key.publicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		default:
+			continue
+		}
+		response.Keys = append(response.Keys, jwk)
+	}
+
+	return response
+}
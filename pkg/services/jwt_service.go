@@ -11,18 +11,34 @@ import (
 
 	"alfredo/tabunganku/config"
 	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/helpers/token"
 )
 
 type TokenType string
 
 const (
-	AccessToken  TokenType = "access"
-	RefreshToken TokenType = "refresh"
+	AccessToken     TokenType = "access"
+	RefreshToken    TokenType = "refresh"
+	MfaPendingToken TokenType = "mfa_pending"
+	WebviewToken    TokenType = "webview"
+	// Impersonation token dipakai admin untuk melihat aplikasi sebagai user lain, hanya bisa membaca
+	ImpersonationToken TokenType = "impersonation"
 
 	defaultAccessTokenExpiry  = 15
 	defaultRefreshTokenExpiry = 60 * 24
+	mfaPendingTokenExpiry     = 5
+	webviewTokenExpiry        = 1
+	impersonationTokenExpiry  = 15
 
 	revokedTokenExpiration = 24 * 7
+
+	// Redis key prefixes for refresh token rotation
+	usedRefreshTokenPrefix   = "refresh_used:"
+	revokedFamilyPrefix      = "revoked_family:"
+	tokenGenerationPrefix    = "token_generation:"
+	usedMfaTokenPrefix       = "mfa_used:"
+	usedWebviewTokenPrefix   = "webview_used:"
+	endedImpersonationPrefix = "impersonation_ended:"
 )
 
 var (
@@ -30,6 +46,7 @@ var (
 	ErrMalformedToken   = errors.New("malformed token")
 	ErrTokenSigning     = errors.New("error signing token")
 	ErrRefreshTokenSign = errors.New("error signing refresh token")
+	ErrRefreshReused    = errors.New("refresh token already used, please login again")
 )
 
 type jwtServiceImpl struct {
@@ -38,24 +55,25 @@ type jwtServiceImpl struct {
 
 // IsTokenExpired checks if a token has expired
 func (j *jwtServiceImpl) IsTokenExpired(token string) bool {
-	newToken, err := j.ValidateToken(token)
-	if err != nil {
-		return true
-	}
-
-	claims, ok := newToken.Claims.(jwt.MapClaims)
-	if !ok {
-		return true
-	}
-
-	expiredTime := time.Unix(int64(claims["exp"].(float64)), 0)
-	return expiredTime.Before(time.Now())
+	// Expiry is part of claims validation, so any parse error means the token can't be used anymore
+	_, err := j.ParseClaims(token)
+	return err != nil
 }
 
 // Revoke invalidates a token by storing it in Redis with an expiration time
 func (j *jwtServiceImpl) Revoke(token string) error {
-	// Store token in Redis with expiration to automatically clean up old tokens
-	if err := j.redisService.Set(token, true); err != nil {
+	expiration := time.Hour * revokedTokenExpiration
+	if claims, err := j.ParseClaims(token); err == nil {
+		expiration = tokenLifetime(claims, expiration)
+	}
+
+	// Token yang sudah kedaluwarsa tidak perlu disimpan
+	if expiration <= 0 {
+		return nil
+	}
+
+	// Store token in Redis until it would expire anyway, so old keys are cleaned up automatically
+	if err := j.redisService.SetWithExpiration(token, true, expiration); err != nil {
 		return fmt.Errorf("failed to revoke token: %w", err)
 	}
 
@@ -71,22 +89,202 @@ func (j *jwtServiceImpl) IsTokenRevoked(token string) bool {
 	return res != ""
 }
 
+// IsFamilyRevoked checks if every token issued from the same login has been revoked
+func (j *jwtServiceImpl) IsFamilyRevoked(tokens string) bool {
+	res, err := j.redisService.Get(revokedFamilyPrefix + tokens)
+	if err != nil {
+		return false
+	}
+	return res != ""
+}
+
+// RevokeFamily invalidates every access and refresh token sharing the same tokens claim
+func (j *jwtServiceImpl) RevokeFamily(tokens string) error {
+	_, refreshExpiry := j.getTokenExpiryTimes()
+	if err := j.redisService.SetWithExpiration(revokedFamilyPrefix+tokens, true, time.Minute*time.Duration(refreshExpiry)); err != nil {
+		return fmt.Errorf("failed to revoke token family: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAll invalidates every token of the user by bumping the token generation
+func (j *jwtServiceImpl) RevokeAll(userUuid string) error {
+	if _, err := j.redisService.Increment(tokenGenerationPrefix + userUuid); err != nil {
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+
+	return nil
+}
+
+// IsSessionRevoked checks if the login the token belongs to has been revoked,
+// either through its family or through a newer token generation of the user
+func (j *jwtServiceImpl) IsSessionRevoked(claims *TokenClaims) bool {
+	if claims.Tokens != "" && j.IsFamilyRevoked(claims.Tokens) {
+		return true
+	}
+
+	return claims.Generation < j.currentGeneration(claims.UserId)
+}
+
+// Refresh exchanges a refresh token for a new token pair of the same family.
+// Every refresh token can be used once, presenting it again revokes the whole family.
+func (j *jwtServiceImpl) Refresh(refreshToken string) (dtos.GenerateTokenResponse, error) {
+	claims, err := j.ParseClaims(refreshToken)
+	if err != nil || claims.Type != RefreshToken || claims.Tokens == "" {
+		return dtos.GenerateTokenResponse{}, ErrInvalidToken
+	}
+
+	if j.IsTokenRevoked(refreshToken) || j.IsSessionRevoked(claims) {
+		return dtos.GenerateTokenResponse{}, ErrInvalidToken
+	}
+
+	expiration := tokenLifetime(claims, time.Minute*defaultRefreshTokenExpiry)
+
+	// SETNX memastikan hanya satu request yang bisa memakai refresh token ini
+	first, err := j.redisService.SetIfNotExists(usedRefreshTokenPrefix+claims.ID, true, expiration)
+	if err != nil {
+		return dtos.GenerateTokenResponse{}, err
+	}
+	if !first {
+		if err := j.RevokeFamily(claims.Tokens); err != nil {
+			return dtos.GenerateTokenResponse{}, err
+		}
+		return dtos.GenerateTokenResponse{}, ErrRefreshReused
+	}
+
+	return j.GenerateToken(claims.UserId, claims.Tokens)
+}
+
+// GenerateMfaToken creates the short-lived token returned by login when the user still has to pass two-factor authentication
+func (j *jwtServiceImpl) GenerateMfaToken(userUuid string) (string, error) {
+	return j.createToken(userUuid, "", j.currentGeneration(userUuid), mfaPendingTokenExpiry, MfaPendingToken, nil, "")
+}
+
+// ParseMfaToken validates an mfa_pending token that has not been exchanged yet and returns its user and token id
+func (j *jwtServiceImpl) ParseMfaToken(mfaToken string) (userUuid string, tokenId string, err error) {
+	claims, err := j.ParseClaims(mfaToken)
+	if err != nil || claims.Type != MfaPendingToken || j.IsSessionRevoked(claims) {
+		return "", "", ErrInvalidToken
+	}
+
+	if used, err := j.redisService.Get(usedMfaTokenPrefix + claims.ID); err == nil && used != "" {
+		return "", "", ErrInvalidToken
+	}
+
+	return claims.UserId, claims.ID, nil
+}
+
+// ConsumeMfaToken marks an mfa_pending token as exchanged, it fails when another request already used it
+func (j *jwtServiceImpl) ConsumeMfaToken(tokenId string) error {
+	first, err := j.redisService.SetIfNotExists(usedMfaTokenPrefix+tokenId, true, time.Minute*mfaPendingTokenExpiry)
+	if err != nil {
+		return err
+	}
+	if !first {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+// GenerateWebviewToken exchanges an access token for a one-time token that can only open the given page.
+// It keeps the login family of the access token, so logging out also invalidates it.
+func (j *jwtServiceImpl) GenerateWebviewToken(claims *TokenClaims, path string) (dtos.WebviewTokenResponse, error) {
+	if claims.Type != AccessToken {
+		return dtos.WebviewTokenResponse{}, ErrInvalidToken
+	}
+
+	path = NormalizeWebviewPath(path)
+	if !IsWebviewPathAllowed(path) {
+		return dtos.WebviewTokenResponse{}, ErrWebviewPathNotAllowed
+	}
+
+	scope := make([]string, 0, len(webviewScopes))
+	for _, s := range webviewScopes {
+		if claims.HasScope(s) {
+			scope = append(scope, s)
+		}
+	}
+
+	token, err := j.createToken(claims.UserId, claims.Tokens, claims.Generation, webviewTokenExpiry, WebviewToken, scope, path)
+	if err != nil {
+		return dtos.WebviewTokenResponse{}, err
+	}
+
+	return dtos.WebviewTokenResponse{
+		Token:     token,
+		ExpiresIn: webviewTokenExpiry * 60,
+		Path:      path,
+	}, nil
+}
+
+// ConsumeWebviewToken marks a webview token as used, it fails when the token was opened before
+func (j *jwtServiceImpl) ConsumeWebviewToken(claims *TokenClaims) error {
+	first, err := j.redisService.SetIfNotExists(usedWebviewTokenPrefix+claims.ID, true, time.Minute*webviewTokenExpiry)
+	if err != nil {
+		return err
+	}
+	if !first {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+// GenerateImpersonationToken creates a read-only token for userUuid that carries the admin acting as the user.
+// It has no login family and no refresh token, the admin has to start a new impersonation once it expires.
+func (j *jwtServiceImpl) GenerateImpersonationToken(adminUuid string, userUuid string) (string, *TokenClaims, error) {
+	claims := newTokenClaims(userUuid, "", j.currentGeneration(userUuid), impersonationTokenExpiry, ImpersonationToken, impersonationScopes, "")
+	claims.ImpersonatorId = adminUuid
+
+	token, err := signToken(claims)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, claims, nil
+}
+
+// EndImpersonation invalidates an impersonation token before it expires
+func (j *jwtServiceImpl) EndImpersonation(tokenId string, expiresAt time.Time) error {
+	expiration := time.Until(expiresAt)
+	if expiration <= 0 {
+		return nil
+	}
+
+	if err := j.redisService.SetWithExpiration(endedImpersonationPrefix+tokenId, true, expiration); err != nil {
+		return fmt.Errorf("failed to end impersonation: %w", err)
+	}
+
+	return nil
+}
+
+// IsImpersonationEnded checks if the impersonation the token belongs to was ended by an admin
+func (j *jwtServiceImpl) IsImpersonationEnded(claims *TokenClaims) bool {
+	res, err := j.redisService.Get(endedImpersonationPrefix + claims.ID)
+	if err != nil {
+		return false
+	}
+	return res != ""
+}
+
 // GenerateToken creates both access and refresh tokens for a user
 func (j *jwtServiceImpl) GenerateToken(userUuid string, tokens string) (dtos.GenerateTokenResponse, error) {
-	// Get JWT configuration
-	secretKey := []byte(config.JwtSecret)
-
 	// Get token expiration times from config or use defaults
 	accessExpiry, refreshExpiry := j.getTokenExpiryTimes()
 
+	// Token lama dengan generation lebih kecil dianggap sudah logout
+	generation := j.currentGeneration(userUuid)
+
 	// Generate access token
-	accessToken, err := j.createToken(userUuid, tokens, accessExpiry, string(AccessToken), secretKey)
+	accessToken, err := j.createToken(userUuid, tokens, generation, accessExpiry, AccessToken, userScopes, "")
 	if err != nil {
 		return dtos.GenerateTokenResponse{}, fmt.Errorf("failed to create access token: %w", err)
 	}
 
 	// Generate refresh token
-	refreshToken, err := j.createToken(userUuid, tokens, refreshExpiry, string(RefreshToken), secretKey)
+	refreshToken, err := j.createToken(userUuid, tokens, generation, refreshExpiry, RefreshToken, userScopes, "")
 	if err != nil {
 		return dtos.GenerateTokenResponse{}, fmt.Errorf("failed to create refresh token: %w", err)
 	}
@@ -101,41 +299,59 @@ func (j *jwtServiceImpl) GenerateToken(userUuid string, tokens string) (dtos.Gen
 
 // ValidateToken verifies a JWT token's signature and format
 func (j *jwtServiceImpl) ValidateToken(token string) (*jwt.Token, error) {
-	secretKey := []byte(config.JwtSecret)
-
 	// Basic format validation
 	segments := strings.Split(token, ".")
 	if len(segments) != 3 {
 		return nil, ErrMalformedToken
 	}
 
-	return jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		// Validate the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrSignatureInvalid
-		}
-		return secretKey, nil
-	})
+	// The key and signing method are chosen from the kid header
+	ring, err := getKeyring()
+	if err != nil {
+		return nil, err
+	}
+
+	return jwt.ParseWithClaims(token, &TokenClaims{}, ring.verificationKey,
+		jwt.WithIssuer(jwtIssuer()),
+		jwt.WithAudience(jwtAudience()),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
 }
 
-// GetUserIdFromToken extracts the user ID from a valid token
-func (j *jwtServiceImpl) GetUserIdFromToken(token string) (string, error) {
-	newToken, err := j.ValidateToken(token)
+// ParseClaims validates the token and returns its claims
+func (j *jwtServiceImpl) ParseClaims(token string) (*TokenClaims, error) {
+	parsed, err := j.ValidateToken(token)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	claims, ok := newToken.Claims.(jwt.MapClaims)
-	if !ok {
-		return "", ErrInvalidToken
+	claims, ok := parsed.Claims.(*TokenClaims)
+	if !ok || !parsed.Valid {
+		return nil, ErrInvalidToken
 	}
 
-	userID, ok := claims["user_id"].(string)
-	if !ok {
-		return "", fmt.Errorf("invalid user_id in token")
+	return claims, nil
+}
+
+// Jwks returns the public keys that verify tokens, including retired keys that are still configured
+func (j *jwtServiceImpl) Jwks() dtos.JwksResponse {
+	ring, err := getKeyring()
+	if err != nil {
+		return dtos.JwksResponse{Keys: []dtos.JwkResponse{}}
 	}
 
-	return userID, nil
+	return ring.jwks()
+}
+
+// GetUserIdFromToken extracts the user ID from a valid token
+func (j *jwtServiceImpl) GetUserIdFromToken(token string) (string, error) {
+	claims, err := j.ParseClaims(token)
+	if err != nil {
+		return "", err
+	}
+
+	return claims.UserId, nil
 }
 
 // Helper methods
@@ -155,23 +371,67 @@ func (j *jwtServiceImpl) getTokenExpiryTimes() (accessExpiry, refreshExpiry int6
 	return
 }
 
+// currentGeneration returns the token generation of the user, zero until the first logout-all
+func (j *jwtServiceImpl) currentGeneration(userUuid string) int64 {
+	res, err := j.redisService.Get(tokenGenerationPrefix + userUuid)
+	if err != nil {
+		return 0
+	}
+
+	generation, _ := strconv.ParseInt(res, 10, 64)
+	return generation
+}
+
+// tokenLifetime returns how long the token is still valid, or fallback when it has no expiry
+func tokenLifetime(claims *TokenClaims, fallback time.Duration) time.Duration {
+	if claims.ExpiresAt == nil {
+		return fallback
+	}
+
+	return time.Until(claims.ExpiresAt.Time)
+}
+
 // createToken generates a signed JWT token with the given parameters
-func (j *jwtServiceImpl) createToken(userUuid string, tokens string, expiry int64, tokenType string, secretKey []byte) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id": userUuid,
-		"tokens":  tokens,
-		"exp":     jwt.NewNumericDate(time.Now().Add(time.Minute * time.Duration(expiry))).Unix(),
-		"iat":     time.Now().Unix(),
-		"type":    tokenType,
+func (j *jwtServiceImpl) createToken(userUuid string, tokens string, generation int64, expiry int64, tokenType TokenType, scope []string, path string) (string, error) {
+	return signToken(newTokenClaims(userUuid, tokens, generation, expiry, tokenType, scope, path))
+}
+
+// newTokenClaims fills the registered and custom claims of a token that expires in expiry minutes
+func newTokenClaims(userUuid string, tokens string, generation int64, expiry int64, tokenType TokenType, scope []string, path string) *TokenClaims {
+	now := time.Now()
+	return &TokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        token.URLSafe(token.IdEntropy),
+			Issuer:    jwtIssuer(),
+			Subject:   userUuid,
+			Audience:  jwt.ClaimStrings{jwtAudience()},
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute * time.Duration(expiry))),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+		UserId:     userUuid,
+		Tokens:     tokens,
+		Generation: generation,
+		Type:       tokenType,
+		Scope:      scope,
+		Path:       path,
 	}
+}
 
-	// Add exp_in only for access tokens
-	if tokenType == string(AccessToken) {
-		claims["exp_in"] = jwt.NewNumericDate(time.Now().Add(time.Minute * time.Duration(expiry))).Second()
+// signToken signs the claims with the active key
+func signToken(claims *TokenClaims) (string, error) {
+	// Kid di header memberi tahu verifier key mana yang dipakai, sehingga key bisa dirotasi
+	ring, err := getKeyring()
+	if err != nil {
+		return "", ErrTokenSigning
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString(secretKey)
+	method, signingKey, kid := ring.signingKey()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signedToken, err := token.SignedString(signingKey)
 	if err != nil {
 		return "", ErrTokenSigning
 	}
@@ -183,9 +443,24 @@ type JwtService interface {
 	IsTokenExpired(token string) bool
 	Revoke(token string) error
 	IsTokenRevoked(token string) bool
+	IsFamilyRevoked(tokens string) bool
+	RevokeFamily(tokens string) error
+	RevokeAll(userUuid string) error
+	IsSessionRevoked(claims *TokenClaims) bool
+	Refresh(refreshToken string) (dtos.GenerateTokenResponse, error)
+	GenerateMfaToken(userUuid string) (string, error)
+	ParseMfaToken(mfaToken string) (userUuid string, tokenId string, err error)
+	ConsumeMfaToken(tokenId string) error
+	GenerateWebviewToken(claims *TokenClaims, path string) (dtos.WebviewTokenResponse, error)
+	ConsumeWebviewToken(claims *TokenClaims) error
+	GenerateImpersonationToken(adminUuid string, userUuid string) (string, *TokenClaims, error)
+	EndImpersonation(tokenId string, expiresAt time.Time) error
+	IsImpersonationEnded(claims *TokenClaims) bool
 	GenerateToken(userUuid string, tokens string) (dtos.GenerateTokenResponse, error)
 	ValidateToken(token string) (*jwt.Token, error)
+	ParseClaims(token string) (*TokenClaims, error)
 	GetUserIdFromToken(token string) (string, error)
+	Jwks() dtos.JwksResponse
 }
 
 func NewJwtService(redisService RedisService) JwtService {
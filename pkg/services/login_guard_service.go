@@ -0,0 +1,233 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+
+	"alfredo/tabunganku/config"
+	"alfredo/tabunganku/pkg/helpers"
+	"alfredo/tabunganku/pkg/helpers/token"
+	"alfredo/tabunganku/pkg/models"
+)
+
+const (
+	// Gagal login dihitung dalam jendela waktu ini
+	loginFailureWindow = 15 * time.Minute
+	// Mulai ada jeda setelah beberapa kali gagal, jedanya naik dua kali lipat
+	loginDelayAfter = 3
+	loginMaxDelay   = time.Minute
+	// Akun dikunci sementara setelah terlalu banyak gagal
+	loginLockAfter    = 10
+	loginLockDuration = 30 * time.Minute
+	// Batas gagal per IP, untuk menahan tebakan ke banyak email sekaligus
+	loginIpLimit = 50
+
+	loginFailedEmailPrefix = "login_failed:email:"
+	loginFailedIpPrefix    = "login_failed:ip:"
+	loginRetryAfterPrefix  = "login_retry_after:"
+	loginLockedPrefix      = "login_locked:"
+	loginUnlockPrefix      = "login_unlock:"
+
+	// Cek password saat sudah login (ganti password, ganti email, hapus akun) dibatasi per user
+	passwordCheckLimit        = 5
+	passwordCheckFailedPrefix = "password_check_failed:"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrAccountLocked      = errors.New("account temporarily locked because of too many failed logins, check your email to unlock it")
+	ErrUnlockTokenInvalid = errors.New("unlock token is invalid or has expired")
+	ErrAccountDisabled    = errors.New("account has been disabled, please contact support")
+
+	ErrPasswordCheckLimited = errors.New("too many wrong passwords, please try again later")
+)
+
+// LoginThrottledError is returned when the client has to wait before trying to login again
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("too many failed logins, try again in %d seconds", int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+// LoginGuardService throttles password guessing with Redis counters per email and per IP
+type LoginGuardService interface {
+	Check(email string, ipAddress string) error
+	RecordFailure(email string, ipAddress string, user *models.User) error
+	RecordSuccess(email string)
+	Unlock(token string) error
+	CheckCurrentPassword(user *models.User, password string) error
+}
+
+type loginGuardServiceImpl struct {
+	redisService  RedisService
+	mailerService MailerService
+}
+
+// Check implements LoginGuardService.
+func (l *loginGuardServiceImpl) Check(email string, ipAddress string) error {
+	email = normalizeEmail(email)
+
+	if locked, _ := l.redisService.Get(loginLockedPrefix + email); locked != "" {
+		return ErrAccountLocked
+	}
+
+	if ipAddress != "" && l.counter(loginFailedIpPrefix+ipAddress) >= loginIpLimit {
+		// Sisa waktu window IP, bukan panjang window penuh
+		retryAfter, err := l.redisService.TTL(loginFailedIpPrefix + ipAddress)
+		if err != nil || retryAfter <= 0 {
+			retryAfter = loginFailureWindow
+		}
+		return &LoginThrottledError{RetryAfter: retryAfter}
+	}
+
+	if retryAt, err := l.redisService.Get(loginRetryAfterPrefix + email); err == nil && retryAt != "" {
+		if at, err := time.Parse(time.RFC3339Nano, retryAt); err == nil && time.Now().Before(at) {
+			return &LoginThrottledError{RetryAfter: time.Until(at)}
+		}
+	}
+
+	return nil
+}
+
+// RecordFailure implements LoginGuardService.
+// The user is nil when the email is not registered, the counters still grow so the response does not differ.
+func (l *loginGuardServiceImpl) RecordFailure(email string, ipAddress string, user *models.User) error {
+	email = normalizeEmail(email)
+
+	if ipAddress != "" {
+		_, _ = l.increment(loginFailedIpPrefix + ipAddress)
+	}
+
+	failures, err := l.increment(loginFailedEmailPrefix + email)
+	if err != nil {
+		return err
+	}
+
+	if failures >= loginLockAfter {
+		if err := l.redisService.SetWithExpiration(loginLockedPrefix+email, true, loginLockDuration); err != nil {
+			return err
+		}
+		_ = l.redisService.Delete(loginFailedEmailPrefix + email)
+
+		if user != nil {
+			if err := l.sendUnlockEmail(user); err != nil {
+				return err
+			}
+		}
+
+		return ErrAccountLocked
+	}
+
+	if failures >= loginDelayAfter {
+		delay := time.Second * time.Duration(1<<(failures-loginDelayAfter))
+		if delay > loginMaxDelay {
+			delay = loginMaxDelay
+		}
+		retryAt := time.Now().Add(delay).Format(time.RFC3339Nano)
+		_ = l.redisService.SetWithExpiration(loginRetryAfterPrefix+email, retryAt, delay)
+	}
+
+	return nil
+}
+
+// RecordSuccess implements LoginGuardService.
+func (l *loginGuardServiceImpl) RecordSuccess(email string) {
+	email = normalizeEmail(email)
+	_ = l.redisService.Delete(loginFailedEmailPrefix + email)
+	_ = l.redisService.Delete(loginRetryAfterPrefix + email)
+}
+
+// Unlock implements LoginGuardService.
+func (l *loginGuardServiceImpl) Unlock(token string) error {
+	if token == "" {
+		return ErrUnlockTokenInvalid
+	}
+
+	key := loginUnlockPrefix + hashOneTimeToken(token)
+	email, err := l.redisService.Get(key)
+	if err != nil || email == "" {
+		return ErrUnlockTokenInvalid
+	}
+
+	_ = l.redisService.Delete(key)
+	_ = l.redisService.Delete(loginLockedPrefix + email)
+	l.RecordSuccess(email)
+
+	return nil
+}
+
+// CheckCurrentPassword implements LoginGuardService.
+// A stolen access token must not allow guessing the password, so wrong passwords are counted per user.
+func (l *loginGuardServiceImpl) CheckCurrentPassword(user *models.User, password string) error {
+	key := passwordCheckFailedPrefix + user.UUID
+	if l.counter(key) >= passwordCheckLimit {
+		return ErrPasswordCheckLimited
+	}
+
+	if ok, err := helpers.CheckPasswordHashWithArgon2(password, user.Password); !ok || err != nil {
+		if _, err := l.increment(key); err != nil {
+			return err
+		}
+		return ErrCurrentPasswordInvalid
+	}
+
+	_ = l.redisService.Delete(key)
+
+	return nil
+}
+
+// sendUnlockEmail mails a single-use link that lifts the lock before it expires on its own
+func (l *loginGuardServiceImpl) sendUnlockEmail(user *models.User) error {
+	rawToken := token.URLSafe(token.LinkEntropy)
+	if err := l.redisService.SetWithExpiration(loginUnlockPrefix+hashOneTimeToken(rawToken), normalizeEmail(user.Email), loginLockDuration); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nYour Tabunganku account was locked for %d minutes after too many failed logins.\n", user.Name, int(loginLockDuration.Minutes()))
+	body += fmt.Sprintf("If this was you, unlock it with this token: %s\n", rawToken)
+	if config.LoginUnlockUrl != "" {
+		body += fmt.Sprintf("\nOr open this link: %s?token=%s\n", config.LoginUnlockUrl, url.QueryEscape(rawToken))
+	}
+	body += "\nIf this was not you, consider resetting your password.\n"
+
+	return l.mailerService.Send(user.Email, "Your Tabunganku account was locked", body)
+}
+
+// counter returns the current value of a failure counter
+func (l *loginGuardServiceImpl) counter(key string) int64 {
+	res, err := l.redisService.Get(key)
+	if err != nil {
+		return 0
+	}
+
+	var value int64
+	_, _ = fmt.Sscan(res, &value)
+	return value
+}
+
+// increment bumps a failure counter, the window starts at the first failure
+func (l *loginGuardServiceImpl) increment(key string) (int64, error) {
+	value, err := l.redisService.Increment(key)
+	if err != nil {
+		return 0, err
+	}
+	if value == 1 {
+		_ = l.redisService.Expire(key, loginFailureWindow)
+	}
+
+	return value, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func NewLoginGuardService(redisService RedisService, mailerService MailerService) LoginGuardService {
+	return &loginGuardServiceImpl{redisService: redisService, mailerService: mailerService}
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"alfredo/tabunganku/config"
+)
+
+const (
+	MailDriverSMTP = "smtp"
+	MailDriverLog  = "log"
+
+	defaultMailLogPath = "./storage/mail.log"
+)
+
+// MailerService sends plain text emails. The implementation is chosen by mail.driver in the config.
+type MailerService interface {
+	Send(to string, subject string, body string) error
+}
+
+type smtpMailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// Send implements MailerService.
+func (m *smtpMailer) Send(to string, subject string, body string) error {
+	message := strings.Join([]string{
+		"From: " + m.from,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"UTF-8\"",
+		"",
+		body,
+	}, "\r\n")
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	if err := smtp.SendMail(net.JoinHostPort(m.host, m.port), auth, m.from, []string{to}, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// logMailer is the local stand-in, emails are appended to a file and written to the log instead of being sent
+type logMailer struct {
+	path string
+}
+
+// Send implements MailerService.
+func (m *logMailer) Send(to string, subject string, body string) error {
+	log.Printf("mail to=%s subject=%q", to, subject)
+
+	if err := os.MkdirAll(filepath.Dir(m.path), 0755); err != nil {
+		return fmt.Errorf("failed to create mail directory: %w", err)
+	}
+
+	file, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open mail log: %w", err)
+	}
+	defer file.Close()
+
+	entry := fmt.Sprintf("Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n----\n", time.Now().Format(time.RFC3339), to, subject, body)
+	if _, err := file.WriteString(entry); err != nil {
+		return fmt.Errorf("failed to write mail log: %w", err)
+	}
+
+	return nil
+}
+
+func NewMailerService() MailerService {
+	if config.MailDriver == MailDriverSMTP {
+		return &smtpMailer{
+			host:     config.MailHost,
+			port:     config.MailPort,
+			username: config.MailUsername,
+			password: config.MailPassword,
+			from:     config.MailFrom,
+		}
+	}
+
+	path := config.MailLogPath
+	if path == "" {
+		path = defaultMailLogPath
+	}
+
+	return &logMailer{path: path}
+}
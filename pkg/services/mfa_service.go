@@ -0,0 +1,204 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/helpers"
+	"alfredo/tabunganku/pkg/helpers/token"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+const (
+	mfaIssuer            = "Tabunganku"
+	mfaQrCodeSize        = 256
+	mfaRecoveryCodeCount = 10
+	mfaMaxAttempts       = 5
+	mfaAttemptWindow     = 5 * time.Minute
+
+	mfaAttemptsPrefix = "mfa_attempts:"
+	totpUsedPrefix    = "totp_used:"
+)
+
+var (
+	ErrMfaNotEnabled      = errors.New("two-factor authentication is not enabled")
+	ErrMfaNotEnrolled     = errors.New("start two-factor enrollment first")
+	ErrMfaInvalidCode     = errors.New("invalid two-factor code")
+	ErrMfaTooManyAttempts = errors.New("too many two-factor attempts, please try again later")
+)
+
+type MfaService interface {
+	Enroll(user *models.User) (*dtos.MfaEnrollResponse, error)
+	Confirm(user *models.User, req *dtos.MfaCodeRequest) (*dtos.MfaRecoveryCodesResponse, error)
+	Disable(user *models.User, req *dtos.MfaCodeRequest) error
+	VerifyCode(user *models.User, code string) error
+}
+
+type mfaServiceImpl struct {
+	repo         repositories.MfaRepository
+	redisService RedisService
+	validator    *validator.CustomValidator
+}
+
+// Enroll implements MfaService.
+func (m *mfaServiceImpl) Enroll(user *models.User) (*dtos.MfaEnrollResponse, error) {
+	if user.TotpEnabledAt != nil {
+		return nil, repositories.ErrMfaAlreadyEnabled
+	}
+
+	secret, err := helpers.GenerateTotpSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	if err := m.repo.SetPendingSecret(user.UUID, secret); err != nil {
+		return nil, err
+	}
+
+	uri := helpers.TotpURI(mfaIssuer, user.Email, secret)
+	png, err := qrcode.Encode(uri, qrcode.Medium, mfaQrCodeSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate qr code: %w", err)
+	}
+
+	return &dtos.MfaEnrollResponse{
+		Secret:     secret,
+		OtpauthURI: uri,
+		QrCode:     base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+// Confirm implements MfaService.
+func (m *mfaServiceImpl) Confirm(user *models.User, req *dtos.MfaCodeRequest) (*dtos.MfaRecoveryCodesResponse, error) {
+	if err := m.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	if user.TotpEnabledAt != nil {
+		return nil, repositories.ErrMfaAlreadyEnabled
+	}
+	if user.TotpSecret == nil {
+		return nil, ErrMfaNotEnrolled
+	}
+
+	if err := m.checkTotp(user, req.Code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes := generateRecoveryCodes()
+
+	if err := m.repo.EnableTotp(user.UUID, hashes); err != nil {
+		return nil, err
+	}
+
+	return &dtos.MfaRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable implements MfaService.
+func (m *mfaServiceImpl) Disable(user *models.User, req *dtos.MfaCodeRequest) error {
+	if err := m.validator.Validate(req); err != nil {
+		return err
+	}
+
+	if err := m.VerifyCode(user, req.Code); err != nil {
+		return err
+	}
+
+	return m.repo.DisableTotp(user.UUID)
+}
+
+// VerifyCode implements MfaService.
+// The code is either the current TOTP code or one of the unused recovery codes.
+func (m *mfaServiceImpl) VerifyCode(user *models.User, code string) error {
+	if user.TotpEnabledAt == nil || user.TotpSecret == nil {
+		return ErrMfaNotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == 6 {
+		return m.checkTotp(user, code)
+	}
+
+	if err := m.countAttempt(user.UUID); err != nil {
+		return err
+	}
+
+	used, err := m.repo.UseRecoveryCode(user.UUID, hashOneTimeToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrMfaInvalidCode
+	}
+
+	return nil
+}
+
+// checkTotp validates a TOTP code and rejects a code that was already used in its time step
+func (m *mfaServiceImpl) checkTotp(user *models.User, code string) error {
+	if err := m.countAttempt(user.UUID); err != nil {
+		return err
+	}
+
+	step, ok := helpers.ValidateTotp(*user.TotpSecret, code, time.Now())
+	if !ok {
+		return ErrMfaInvalidCode
+	}
+
+	first, err := m.redisService.SetIfNotExists(fmt.Sprintf("%s%s:%d", totpUsedPrefix, user.UUID, step), true, 2*time.Minute)
+	if err != nil {
+		return err
+	}
+	if !first {
+		return ErrMfaInvalidCode
+	}
+
+	return nil
+}
+
+// countAttempt limits how many codes can be tried per user within the attempt window
+func (m *mfaServiceImpl) countAttempt(userUuid string) error {
+	attempts, err := m.redisService.Increment(mfaAttemptsPrefix + userUuid)
+	if err != nil {
+		return err
+	}
+	if attempts == 1 {
+		_ = m.redisService.Expire(mfaAttemptsPrefix+userUuid, mfaAttemptWindow)
+	}
+	if attempts > mfaMaxAttempts {
+		return ErrMfaTooManyAttempts
+	}
+
+	return nil
+}
+
+// generateRecoveryCodes returns the plain codes shown once to the user and the hashes to store
+func generateRecoveryCodes() (codes []string, hashes []string) {
+	const alphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+	for i := 0; i < mfaRecoveryCodeCount; i++ {
+		raw := token.FromAlphabet(alphabet, 10)
+
+		code := raw[:5] + "-" + raw[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashOneTimeToken(normalizeRecoveryCode(code)))
+	}
+
+	return codes, hashes
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
+
+func NewMfaService(repo repositories.MfaRepository, redisService RedisService, validator *validator.CustomValidator) MfaService {
+	return &mfaServiceImpl{repo: repo, redisService: redisService, validator: validator}
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"fmt"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+type MilestoneService interface {
+	ReplaceMilestones(userUuid string, savingUuid string, req *dtos.SavingMilestonesRequest) (*dtos.SavingTimelineResponse, error)
+	GetTimeline(userUuid string, savingUuid string) (*dtos.SavingTimelineResponse, error)
+}
+
+type milestoneServiceImpl struct {
+	repo      repositories.MilestoneRepository
+	validator *validator.CustomValidator
+}
+
+// ReplaceMilestones implements MilestoneService.
+func (m *milestoneServiceImpl) ReplaceMilestones(userUuid string, savingUuid string, req *dtos.SavingMilestonesRequest) (*dtos.SavingTimelineResponse, error) {
+	if err := m.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[float64]bool)
+	for _, milestone := range req.Milestones {
+		if seen[milestone.Percentage] {
+			return nil, &validator.ValidationError{Message: fmt.Sprintf("milestones: duplicate percentage %g", milestone.Percentage)}
+		}
+		seen[milestone.Percentage] = true
+	}
+
+	return m.repo.ReplaceMilestones(userUuid, savingUuid, req.Milestones)
+}
+
+// GetTimeline implements MilestoneService.
+func (m *milestoneServiceImpl) GetTimeline(userUuid string, savingUuid string) (*dtos.SavingTimelineResponse, error) {
+	return m.repo.GetTimeline(userUuid, savingUuid)
+}
+
+func NewMilestoneService(repo repositories.MilestoneRepository, validator *validator.CustomValidator) MilestoneService {
+	return &milestoneServiceImpl{repo: repo, validator: validator}
+}
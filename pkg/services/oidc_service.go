@@ -0,0 +1,389 @@
+package services
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"alfredo/tabunganku/config"
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/helpers/token"
+)
+
+const (
+	oidcStatePrefix     = "oidc_state:"
+	oidcStateExpiration = 10 * time.Minute
+
+	// JWKS provider di-cache, tapi kid yang belum dikenal memicu fetch ulang paling cepat tiap menit
+	oidcJwksCacheExpiration = time.Hour
+	oidcJwksRefetchInterval = time.Minute
+)
+
+var (
+	ErrOidcProviderNotFound  = errors.New("login provider not found")
+	ErrOidcStateInvalid      = errors.New("login request expired, please try again")
+	ErrOidcTokenInvalid      = errors.New("invalid id token from login provider")
+	ErrOidcEmailNotVerified  = errors.New("email is not verified by the login provider")
+	ErrOidcProvider          = errors.New("login provider returned an error")
+	ErrOidcAccountUnverified = errors.New("an account with this email is not verified yet, log in with your password and verify the email before using social login")
+)
+
+// OidcIdentity is the verified user information taken from an ID token
+type OidcIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// OidcService runs the authorization code flow with PKCE against the configured providers
+type OidcService interface {
+	AuthorizationUrl(provider string) (dtos.OidcAuthorizationResponse, error)
+	Exchange(provider string, code string, state string) (*OidcIdentity, error)
+}
+
+type oidcState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// oidcIdTokenClaims are the ID token claims used for login. Apple sends email_verified as a string.
+type oidcIdTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string      `json:"nonce"`
+	Email         string      `json:"email"`
+	EmailVerified interface{} `json:"email_verified"`
+	Name          string      `json:"name"`
+}
+
+type oidcProvider struct {
+	name   string
+	config config.OidcProviderConfig
+
+	mu            sync.Mutex
+	discovered    bool
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+var (
+	oidcProvidersOnce sync.Once
+	oidcProviders     map[string]*oidcProvider
+)
+
+func getOidcProviders() map[string]*oidcProvider {
+	oidcProvidersOnce.Do(func() {
+		oidcProviders = make(map[string]*oidcProvider)
+		for name, providerConfig := range config.GetOidcProviders() {
+			oidcProviders[name] = &oidcProvider{name: name, config: providerConfig}
+		}
+	})
+
+	return oidcProviders
+}
+
+type oidcServiceImpl struct {
+	redisService RedisService
+	client       *http.Client
+}
+
+// AuthorizationUrl implements OidcService.
+func (o *oidcServiceImpl) AuthorizationUrl(provider string) (dtos.OidcAuthorizationResponse, error) {
+	p, ok := getOidcProviders()[provider]
+	if !ok {
+		return dtos.OidcAuthorizationResponse{}, ErrOidcProviderNotFound
+	}
+	if err := o.discover(p); err != nil {
+		return dtos.OidcAuthorizationResponse{}, err
+	}
+
+	state := token.URLSafe(token.IdEntropy)
+	nonce := token.URLSafe(token.IdEntropy)
+	// PKCE verifier harus 43-128 karakter, 256 bit base64url menghasilkan 43 karakter
+	verifier := token.URLSafe(token.SessionEntropy)
+
+	payload, err := json.Marshal(oidcState{Provider: provider, Nonce: nonce, CodeVerifier: verifier})
+	if err != nil {
+		return dtos.OidcAuthorizationResponse{}, err
+	}
+	if err := o.redisService.SetWithExpiration(oidcStatePrefix+state, string(payload), oidcStateExpiration); err != nil {
+		return dtos.OidcAuthorizationResponse{}, fmt.Errorf("failed to store login state: %w", err)
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+	scopes := p.config.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientId)
+	query.Set("redirect_uri", p.config.RedirectUrl)
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	if p.config.ResponseMode != "" {
+		query.Set("response_mode", p.config.ResponseMode)
+	}
+
+	separator := "?"
+	if strings.Contains(p.config.AuthorizationUrl, "?") {
+		separator = "&"
+	}
+
+	return dtos.OidcAuthorizationResponse{
+		AuthorizationUrl: p.config.AuthorizationUrl + separator + query.Encode(),
+		State:            state,
+	}, nil
+}
+
+// Exchange implements OidcService.
+func (o *oidcServiceImpl) Exchange(provider string, code string, state string) (*OidcIdentity, error) {
+	p, ok := getOidcProviders()[provider]
+	if !ok {
+		return nil, ErrOidcProviderNotFound
+	}
+	if code == "" || state == "" {
+		return nil, ErrOidcStateInvalid
+	}
+
+	// State hanya bisa dipakai sekali
+	raw, err := o.redisService.Get(oidcStatePrefix + state)
+	if err != nil || raw == "" {
+		return nil, ErrOidcStateInvalid
+	}
+	_ = o.redisService.Delete(oidcStatePrefix + state)
+
+	var saved oidcState
+	if err := json.Unmarshal([]byte(raw), &saved); err != nil || saved.Provider != provider {
+		return nil, ErrOidcStateInvalid
+	}
+
+	if err := o.discover(p); err != nil {
+		return nil, err
+	}
+
+	idToken, err := o.exchangeCode(p, code, saved.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims oidcIdTokenClaims
+	_, err = jwt.ParseWithClaims(idToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return o.publicKey(p, kid)
+	},
+		jwt.WithIssuer(p.config.Issuer),
+		jwt.WithAudience(p.config.ClientId),
+		jwt.WithExpirationRequired(),
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "EdDSA"}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOidcTokenInvalid, err)
+	}
+	if claims.Subject == "" || !token.Equal(claims.Nonce, saved.Nonce) {
+		return nil, ErrOidcTokenInvalid
+	}
+
+	emailVerified := false
+	switch verified := claims.EmailVerified.(type) {
+	case bool:
+		emailVerified = verified
+	case string:
+		emailVerified = verified == "true"
+	}
+
+	return &OidcIdentity{
+		Provider:      provider,
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(claims.Email),
+		EmailVerified: emailVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+// exchangeCode trades the authorization code for the ID token at the token endpoint
+func (o *oidcServiceImpl) exchangeCode(p *oidcProvider, code string, codeVerifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectUrl)
+	form.Set("client_id", p.config.ClientId)
+	form.Set("client_secret", p.config.ClientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	res, err := o.client.PostForm(p.config.TokenUrl, form)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrOidcProvider, err)
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		IdToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("%w: status %d", ErrOidcProvider, res.StatusCode)
+	}
+	if res.StatusCode >= http.StatusBadRequest || body.Error != "" {
+		return "", fmt.Errorf("%w: %s %s", ErrOidcProvider, body.Error, body.ErrorDescription)
+	}
+	if body.IdToken == "" {
+		return "", ErrOidcTokenInvalid
+	}
+
+	return body.IdToken, nil
+}
+
+// discover fills the endpoints missing from config with the provider discovery document
+func (o *oidcServiceImpl) discover(p *oidcProvider) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovered || (p.config.AuthorizationUrl != "" && p.config.TokenUrl != "" && p.config.JwksUrl != "") {
+		p.discovered = true
+		return nil
+	}
+
+	var document struct {
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		JwksUri               string `json:"jwks_uri"`
+	}
+	if err := o.getJson(strings.TrimRight(p.config.Issuer, "/")+"/.well-known/openid-configuration", &document); err != nil {
+		return err
+	}
+
+	if p.config.AuthorizationUrl == "" {
+		p.config.AuthorizationUrl = document.AuthorizationEndpoint
+	}
+	if p.config.TokenUrl == "" {
+		p.config.TokenUrl = document.TokenEndpoint
+	}
+	if p.config.JwksUrl == "" {
+		p.config.JwksUrl = document.JwksUri
+	}
+	p.discovered = true
+
+	return nil
+}
+
+// publicKey returns the provider key with the given kid, fetching the JWKS again when the key is unknown
+func (o *oidcServiceImpl) publicKey(p *oidcProvider, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key, ok := p.keys[kid]
+	expired := time.Since(p.keysFetchedAt) > oidcJwksCacheExpiration
+	if ok && !expired {
+		return key, nil
+	}
+
+	if !expired && time.Since(p.keysFetchedAt) < oidcJwksRefetchInterval {
+		return nil, ErrOidcTokenInvalid
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Crv string `json:"crv"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	if err := o.getJson(p.config.JwksUrl, &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		switch jwk.Kty {
+		case "RSA":
+			n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+			e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+			if errN != nil || errE != nil {
+				continue
+			}
+			keys[jwk.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		case "EC":
+			var curve elliptic.Curve
+			switch jwk.Crv {
+			case "P-256":
+				curve = elliptic.P256()
+			case "P-384":
+				curve = elliptic.P384()
+			default:
+				continue
+			}
+			x, errX := base64.RawURLEncoding.DecodeString(jwk.X)
+			y, errY := base64.RawURLEncoding.DecodeString(jwk.Y)
+			if errX != nil || errY != nil {
+				continue
+			}
+			keys[jwk.Kid] = &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		case "OKP":
+			x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+			if err != nil || jwk.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+				continue
+			}
+			keys[jwk.Kid] = ed25519.PublicKey(x)
+		}
+	}
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	return nil, ErrOidcTokenInvalid
+}
+
+func (o *oidcServiceImpl) getJson(url string, target interface{}) error {
+	res, err := o.client.Get(url)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrOidcProvider, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%w: status %d", ErrOidcProvider, res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(target); err != nil {
+		return fmt.Errorf("%w: %v", ErrOidcProvider, err)
+	}
+
+	return nil
+}
+
+func NewOidcService(redisService RedisService) OidcService {
+	return &oidcServiceImpl{
+		redisService: redisService,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
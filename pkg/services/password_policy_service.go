@@ -0,0 +1,177 @@
+package services
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"alfredo/tabunganku/config"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+const defaultPasswordMinLength = 8
+
+// Kata personal yang lebih pendek dari ini terlalu umum untuk dilarang
+const minPersonalWordLength = 3
+
+type PasswordPolicyService interface {
+	// Check validates a new password. Personal contains the name and email of the account,
+	// which may not be part of the password.
+	Check(password string, personal ...string) error
+}
+
+type passwordPolicyServiceImpl struct {
+	policy config.PasswordPolicyConfig
+}
+
+// Check implements PasswordPolicyService.
+// Every broken rule is listed in the returned validation error.
+func (p *passwordPolicyServiceImpl) Check(password string, personal ...string) error {
+	var problems []string
+
+	if len([]rune(password)) < p.policy.MinLength {
+		problems = append(problems, fmt.Sprintf("should be at least %d characters", p.policy.MinLength))
+	}
+
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.policy.RequireLowercase && !hasLower {
+		problems = append(problems, "should contain a lowercase letter")
+	}
+	if p.policy.RequireUppercase && !hasUpper {
+		problems = append(problems, "should contain an uppercase letter")
+	}
+	if p.policy.RequireDigit && !hasDigit {
+		problems = append(problems, "should contain a digit")
+	}
+	if p.policy.RequireSymbol && !hasSymbol {
+		problems = append(problems, "should contain a symbol")
+	}
+
+	lowered := strings.ToLower(password)
+	for _, word := range p.policy.BannedWords {
+		if word != "" && strings.Contains(lowered, strings.ToLower(word)) {
+			problems = append(problems, fmt.Sprintf("should not contain %q", word))
+			break
+		}
+	}
+	for _, word := range personalWords(personal) {
+		if strings.Contains(lowered, word) {
+			problems = append(problems, "should not contain your name or email")
+			break
+		}
+	}
+
+	if len(problems) == 0 && p.isBreached(password) {
+		problems = append(problems, "has appeared in a data breach, choose another one")
+	}
+
+	if len(problems) > 0 {
+		return &validator.ValidationError{Message: "password: " + strings.Join(problems, ", ")}
+	}
+
+	return nil
+}
+
+// personalWords splits names and emails into the lowercase words that are checked against the password
+func personalWords(values []string) []string {
+	var words []string
+	for _, value := range values {
+		value = strings.ToLower(value)
+		if local, _, ok := strings.Cut(value, "@"); ok {
+			value = local
+		}
+		for _, word := range strings.FieldsFunc(value, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if len([]rune(word)) >= minPersonalWordLength {
+				words = append(words, word)
+			}
+		}
+	}
+
+	return words
+}
+
+// isBreached looks the SHA-1 of the password up in the local breached list.
+// Like the k-anonymity range API only the 5 character prefix selects which hashes are read.
+// A directory holds one <PREFIX>.txt file of SUFFIX:COUNT lines per prefix,
+// a single file holds HASH:COUNT lines. A missing or unreadable list does not block the password.
+func (p *passwordPolicyServiceImpl) isBreached(password string) bool {
+	if p.policy.BreachedListPath == "" {
+		return false
+	}
+
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	info, err := os.Stat(p.policy.BreachedListPath)
+	if err != nil {
+		log.Println("Error while reading breached password list", "error", err)
+		return false
+	}
+
+	path, match := p.policy.BreachedListPath, hash
+	if info.IsDir() {
+		path, match = filepath.Join(p.policy.BreachedListPath, prefix+".txt"), suffix
+	}
+
+	found, err := containsHash(path, prefix, match, info.IsDir())
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Println("Error while reading breached password list", "error", err)
+		}
+		return false
+	}
+
+	return found
+}
+
+func containsHash(path string, prefix string, match string, rangeFile bool) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.ToUpper(strings.TrimSpace(scanner.Text()))
+		if !rangeFile && !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		if value, _, _ := strings.Cut(line, ":"); value == match {
+			return true, nil
+		}
+	}
+
+	return false, scanner.Err()
+}
+
+func NewPasswordPolicyService() PasswordPolicyService {
+	policy := config.GetPasswordPolicy()
+	if policy.MinLength <= 0 {
+		policy.MinLength = defaultPasswordMinLength
+	}
+
+	return &passwordPolicyServiceImpl{policy: policy}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"alfredo/tabunganku/config"
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/helpers"
+	"alfredo/tabunganku/pkg/helpers/token"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+const (
+	defaultPasswordResetExpiry = 30
+	// Satu email reset per alamat dalam jeda ini, berlaku juga untuk email yang tidak terdaftar
+	passwordResetCooldown       = time.Minute
+	passwordResetCooldownPrefix = "password_reset_cooldown:"
+)
+
+var ErrPasswordResetRateLimited = errors.New("too many password reset requests, please try again later")
+
+type PasswordService interface {
+	ForgotPassword(req *dtos.ForgotPasswordRequest) error
+	SendResetEmail(user *models.User) error
+	ResetPassword(req *dtos.ResetPasswordRequest) error
+}
+
+type passwordServiceImpl struct {
+	repo              repositories.PasswordResetRepository
+	userRepository    repositories.UserRepository
+	sessionRepository repositories.SessionRepository
+	jwtService        JwtService
+	mailerService     MailerService
+	redisService      RedisService
+	passwordPolicy    PasswordPolicyService
+	validator         *validator.CustomValidator
+}
+
+// ForgotPassword implements PasswordService.
+func (p *passwordServiceImpl) ForgotPassword(req *dtos.ForgotPasswordRequest) error {
+	if err := p.validator.Validate(req); err != nil {
+		return err
+	}
+
+	first, err := p.redisService.SetIfNotExists(passwordResetCooldownPrefix+normalizeEmail(req.Email), true, passwordResetCooldown)
+	if err != nil {
+		return err
+	}
+	if !first {
+		return ErrPasswordResetRateLimited
+	}
+
+	// Jangan bocorkan apakah email terdaftar atau tidak
+	user, err := p.userRepository.FindUserByEmail(req.Email)
+	if err != nil {
+		return nil
+	}
+
+	// Email dikirim di background agar waktu respons sama untuk email terdaftar dan tidak
+	go func() {
+		if err := p.SendResetEmail(user); err != nil {
+			log.Println("Error while sending password reset email", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// SendResetEmail implements PasswordService.
+// It creates a reset token and mails it to the user without the per-email cooldown of ForgotPassword.
+func (p *passwordServiceImpl) SendResetEmail(user *models.User) error {
+	rawToken := token.URLSafe(token.LinkEntropy)
+	expiry := p.getResetExpiry()
+	if err := p.repo.CreateResetToken(user.UUID, hashOneTimeToken(rawToken), time.Now().Add(expiry)); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nUse this token to reset your Tabunganku password: %s\n", user.Name, rawToken)
+	if config.PasswordResetUrl != "" {
+		body += fmt.Sprintf("\nOr open this link: %s?token=%s\n", config.PasswordResetUrl, url.QueryEscape(rawToken))
+	}
+	body += fmt.Sprintf("\nThe token expires in %d minutes and can only be used once. If you did not ask for this, ignore this email.\n", int(expiry.Minutes()))
+
+	return p.mailerService.Send(user.Email, "Reset your Tabunganku password", body)
+}
+
+// ResetPassword implements PasswordService.
+func (p *passwordServiceImpl) ResetPassword(req *dtos.ResetPasswordRequest) error {
+	if err := p.validator.Validate(req); err != nil {
+		return err
+	}
+
+	// Token dicek dulu agar password bisa dibandingkan dengan nama dan email pemiliknya
+	token, err := p.repo.FindResetToken(hashOneTimeToken(req.Token))
+	if err != nil {
+		return err
+	}
+	user, err := p.userRepository.FindUserByUuid(token.UserUUID)
+	if err != nil {
+		return repositories.ErrResetTokenInvalid
+	}
+	if err := p.passwordPolicy.Check(req.Password, user.Name, user.Email); err != nil {
+		return err
+	}
+
+	hashedPassword, err := helpers.HashPassword(req.Password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	userUuid, err := p.repo.ConsumeResetToken(hashOneTimeToken(req.Token), hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	// Semua sesi lama harus login ulang dengan password baru
+	if err := p.jwtService.RevokeAll(userUuid); err != nil {
+		return err
+	}
+
+	return p.sessionRepository.RevokeAllSessions(userUuid)
+}
+
+// getResetExpiry returns the configured lifetime of a reset token
+func (p *passwordServiceImpl) getResetExpiry() time.Duration {
+	minutes, err := strconv.Atoi(config.PasswordResetExpire)
+	if err != nil || minutes <= 0 {
+		minutes = defaultPasswordResetExpiry
+	}
+
+	return time.Minute * time.Duration(minutes)
+}
+
+// hashOneTimeToken returns the value stored in the database, the raw token only lives in the email
+func hashOneTimeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func NewPasswordService(
+	repo repositories.PasswordResetRepository,
+	userRepository repositories.UserRepository,
+	sessionRepository repositories.SessionRepository,
+	jwtService JwtService,
+	mailerService MailerService,
+	redisService RedisService,
+	passwordPolicy PasswordPolicyService,
+	validator *validator.CustomValidator,
+) PasswordService {
+	return &passwordServiceImpl{
+		repo:              repo,
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+		jwtService:        jwtService,
+		mailerService:     mailerService,
+		redisService:      redisService,
+		passwordPolicy:    passwordPolicy,
+		validator:         validator,
+	}
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+var (
+	ErrPhoneAlreadyVerified = errors.New("phone number already verified")
+	ErrPhoneNumberMissing   = errors.New("no phone number on this account")
+)
+
+type PhoneVerificationService interface {
+	SendOtp(user *models.User) error
+	VerifyOtp(user *models.User, req *dtos.VerifyOtpRequest) error
+}
+
+type phoneVerificationServiceImpl struct {
+	userRepository repositories.UserRepository
+	otpService     OtpService
+	smsSender      SmsSenderService
+	validator      *validator.CustomValidator
+}
+
+// SendOtp implements PhoneVerificationService.
+func (p *phoneVerificationServiceImpl) SendOtp(user *models.User) error {
+	phoneNumber, err := p.phoneNumber(user)
+	if err != nil {
+		return err
+	}
+
+	code, err := p.otpService.Generate(OtpPurposePhoneVerification, user.UUID)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Your Tabunganku verification code is %s. Do not share this code with anyone.", code)
+	return p.smsSender.Send(phoneNumber, message)
+}
+
+// VerifyOtp implements PhoneVerificationService.
+func (p *phoneVerificationServiceImpl) VerifyOtp(user *models.User, req *dtos.VerifyOtpRequest) error {
+	if err := p.validator.Validate(req); err != nil {
+		return err
+	}
+
+	if _, err := p.phoneNumber(user); err != nil {
+		return err
+	}
+
+	if err := p.otpService.Verify(OtpPurposePhoneVerification, user.UUID, req.Code); err != nil {
+		return err
+	}
+
+	return p.userRepository.MarkPhoneVerified(user.UUID)
+}
+
+// phoneNumber returns the number to verify, or an error when there is nothing to verify
+func (p *phoneVerificationServiceImpl) phoneNumber(user *models.User) (string, error) {
+	if user.PhoneVerifiedAt != nil {
+		return "", ErrPhoneAlreadyVerified
+	}
+	if user.PhoneNumber == nil || *user.PhoneNumber == "" {
+		return "", ErrPhoneNumberMissing
+	}
+
+	return *user.PhoneNumber, nil
+}
+
+func NewPhoneVerificationService(
+	userRepository repositories.UserRepository,
+	otpService OtpService,
+	smsSender SmsSenderService,
+	validator *validator.CustomValidator,
+) PhoneVerificationService {
+	return &phoneVerificationServiceImpl{
+		userRepository: userRepository,
+		otpService:     otpService,
+		smsSender:      smsSender,
+		validator:      validator,
+	}
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"alfredo/tabunganku/config"
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/helpers"
+	"alfredo/tabunganku/pkg/helpers/token"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+const (
+	emailChangeCooldown       = time.Minute
+	emailChangeCooldownPrefix = "email_change_cooldown:"
+)
+
+var (
+	ErrCurrentPasswordInvalid = errors.New("current password is incorrect")
+	ErrEmailUnchanged         = errors.New("new email is the same as the current email")
+	ErrEmailChangeRateLimited = errors.New("too many email change requests, please try again later")
+)
+
+// ProfileService manages the account of the logged in user
+type ProfileService interface {
+	GetProfile(user *models.User) dtos.UserResponse
+	UpdateProfile(user *models.User, req *dtos.UpdateProfileRequest) (dtos.UserResponse, error)
+	ChangePassword(user *models.User, currentTokens string, req *dtos.ChangePasswordRequest) error
+	RequestEmailChange(user *models.User, req *dtos.ChangeEmailRequest) error
+	ConfirmEmailChange(token string) error
+}
+
+type profileServiceImpl struct {
+	userRepository        repositories.UserRepository
+	emailChangeRepository repositories.EmailChangeRepository
+	sessionRepository     repositories.SessionRepository
+	jwtService            JwtService
+	redisService          RedisService
+	mailerService         MailerService
+	otpService            OtpService
+	loginGuard            LoginGuardService
+	passwordPolicy        PasswordPolicyService
+	validator             *validator.CustomValidator
+}
+
+// GetProfile implements ProfileService.
+func (p *profileServiceImpl) GetProfile(user *models.User) dtos.UserResponse {
+	return repositories.ToUserResponse(user)
+}
+
+// UpdateProfile implements ProfileService.
+func (p *profileServiceImpl) UpdateProfile(user *models.User, req *dtos.UpdateProfileRequest) (dtos.UserResponse, error) {
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		req.Name = &name
+	}
+	if req.PhoneNumber != nil {
+		phoneNumber := strings.TrimSpace(*req.PhoneNumber)
+		req.PhoneNumber = &phoneNumber
+	}
+	if err := p.validator.Validate(req); err != nil {
+		return dtos.UserResponse{}, err
+	}
+
+	previousPhone := ""
+	if user.PhoneNumber != nil {
+		previousPhone = *user.PhoneNumber
+	}
+
+	updated, err := p.userRepository.UpdateProfile(user.UUID, req)
+	if err != nil {
+		return dtos.UserResponse{}, err
+	}
+
+	// Kode yang dikirim ke nomor lama tidak boleh memverifikasi nomor baru
+	if updated.PhoneNumber != nil && *updated.PhoneNumber != previousPhone {
+		if err := p.otpService.Invalidate(OtpPurposePhoneVerification, user.UUID); err != nil {
+			return dtos.UserResponse{}, err
+		}
+	}
+
+	return repositories.ToUserResponse(updated), nil
+}
+
+// ChangePassword implements ProfileService.
+// The current session stays logged in, every other session has to login again with the new password.
+func (p *profileServiceImpl) ChangePassword(user *models.User, currentTokens string, req *dtos.ChangePasswordRequest) error {
+	if err := p.validator.Validate(req); err != nil {
+		return err
+	}
+
+	if err := p.loginGuard.CheckCurrentPassword(user, req.CurrentPassword); err != nil {
+		return err
+	}
+
+	if err := p.passwordPolicy.Check(req.Password, user.Name, user.Email); err != nil {
+		return err
+	}
+
+	hashedPassword, err := helpers.HashPassword(req.Password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	if err := p.userRepository.UpdatePassword(user.UUID, hashedPassword); err != nil {
+		return err
+	}
+
+	sessions, err := p.sessionRepository.GetSessions(user.UUID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if session.Tokens == currentTokens {
+			continue
+		}
+		if err := p.jwtService.RevokeFamily(session.Tokens); err != nil {
+			return err
+		}
+		if err := p.sessionRepository.RevokeSession(session.Tokens); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RequestEmailChange implements ProfileService.
+// The email only changes after the link sent to the new address is opened, the old address is notified.
+func (p *profileServiceImpl) RequestEmailChange(user *models.User, req *dtos.ChangeEmailRequest) error {
+	req.Email = strings.TrimSpace(req.Email)
+	if err := p.validator.Validate(req); err != nil {
+		return err
+	}
+
+	if err := p.loginGuard.CheckCurrentPassword(user, req.Password); err != nil {
+		return err
+	}
+
+	if strings.EqualFold(req.Email, user.Email) {
+		return ErrEmailUnchanged
+	}
+
+	taken, err := p.userRepository.IsEmailTaken(req.Email)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return repositories.ErrEmailTaken
+	}
+
+	first, err := p.redisService.SetIfNotExists(emailChangeCooldownPrefix+user.UUID, true, emailChangeCooldown)
+	if err != nil {
+		return err
+	}
+	if !first {
+		return ErrEmailChangeRateLimited
+	}
+
+	rawToken := token.URLSafe(token.LinkEntropy)
+	expiry := emailVerificationExpiry()
+	if err := p.emailChangeRepository.CreateEmailChangeToken(user.UUID, req.Email, hashOneTimeToken(rawToken), time.Now().Add(expiry)); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nUse this token to confirm %s as your new Tabunganku email address: %s\n", user.Name, req.Email, rawToken)
+	if config.EmailChangeUrl != "" {
+		body += fmt.Sprintf("\nOr open this link: %s?token=%s\n", config.EmailChangeUrl, url.QueryEscape(rawToken))
+	}
+	body += fmt.Sprintf("\nThe token expires in %d hours.\n", int(expiry.Hours()))
+	if err := p.mailerService.Send(req.Email, "Confirm your new Tabunganku email address", body); err != nil {
+		return err
+	}
+
+	notice := fmt.Sprintf("Hi %s,\n\nA request was made to change the email address of your Tabunganku account to %s.\n", user.Name, req.Email)
+	notice += "\nNothing changes until the new address is confirmed. If this was not you, change your password.\n"
+
+	return p.mailerService.Send(user.Email, "Your Tabunganku email address is being changed", notice)
+}
+
+// ConfirmEmailChange implements ProfileService.
+func (p *profileServiceImpl) ConfirmEmailChange(token string) error {
+	if token == "" {
+		return repositories.ErrEmailChangeTokenInvalid
+	}
+
+	_, err := p.emailChangeRepository.ConsumeEmailChangeToken(hashOneTimeToken(token))
+	return err
+}
+
+func NewProfileService(
+	userRepository repositories.UserRepository,
+	emailChangeRepository repositories.EmailChangeRepository,
+	sessionRepository repositories.SessionRepository,
+	jwtService JwtService,
+	redisService RedisService,
+	mailerService MailerService,
+	otpService OtpService,
+	loginGuard LoginGuardService,
+	passwordPolicy PasswordPolicyService,
+	validator *validator.CustomValidator,
+) ProfileService {
+	return &profileServiceImpl{
+		userRepository:        userRepository,
+		emailChangeRepository: emailChangeRepository,
+		sessionRepository:     sessionRepository,
+		jwtService:            jwtService,
+		redisService:          redisService,
+		mailerService:         mailerService,
+		otpService:            otpService,
+		loginGuard:            loginGuard,
+		passwordPolicy:        passwordPolicy,
+		validator:             validator,
+	}
+}
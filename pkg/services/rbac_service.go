@@ -0,0 +1,74 @@
+package services
+
+import (
+	"slices"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+// Permissions checked by jwt.RequirePermission, seeded in databases/seeders/roles_seed.sql
+const (
+	PermissionUsersRead        = "users:read"
+	PermissionUsersWrite       = "users:write"
+	PermissionRolesManage      = "roles:manage"
+	PermissionUsersImpersonate = "users:impersonate"
+	PermissionCurrenciesWrite  = "currencies:write"
+)
+
+type RbacService interface {
+	GetRoles() ([]dtos.RoleResponse, error)
+	AssignRole(userUuid string, req *dtos.AssignRoleRequest) error
+	RemoveRole(userUuid string, roleName string) error
+	HasPermissions(userUuid string, permissions ...string) (bool, error)
+}
+
+type rbacServiceImpl struct {
+	repo           repositories.RoleRepository
+	userRepository repositories.UserRepository
+	validator      *validator.CustomValidator
+}
+
+// GetRoles implements RbacService.
+func (r *rbacServiceImpl) GetRoles() ([]dtos.RoleResponse, error) {
+	return r.repo.GetRoles()
+}
+
+// AssignRole implements RbacService.
+func (r *rbacServiceImpl) AssignRole(userUuid string, req *dtos.AssignRoleRequest) error {
+	if err := r.validator.Validate(req); err != nil {
+		return err
+	}
+
+	if _, err := r.userRepository.FindUserByUuid(userUuid); err != nil {
+		return err
+	}
+
+	return r.repo.AssignRole(userUuid, req.Role)
+}
+
+// RemoveRole implements RbacService.
+func (r *rbacServiceImpl) RemoveRole(userUuid string, roleName string) error {
+	return r.repo.RemoveRole(userUuid, roleName)
+}
+
+// HasPermissions implements RbacService.
+func (r *rbacServiceImpl) HasPermissions(userUuid string, permissions ...string) (bool, error) {
+	granted, err := r.repo.GetUserPermissions(userUuid)
+	if err != nil {
+		return false, err
+	}
+
+	for _, permission := range permissions {
+		if !slices.Contains(granted, permission) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func NewRbacService(repo repositories.RoleRepository, userRepository repositories.UserRepository, validator *validator.CustomValidator) RbacService {
+	return &rbacServiceImpl{repo: repo, userRepository: userRepository, validator: validator}
+}
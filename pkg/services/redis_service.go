@@ -1,10 +1,19 @@
 package services
 
-import "alfredo/tabunganku/pkg/repositories"
+import (
+	"time"
+
+	"alfredo/tabunganku/pkg/repositories"
+)
 
 type RedisService interface {
 	Set(key string, value interface{}) error
+	SetWithExpiration(key string, value interface{}, expiration time.Duration) error
+	SetIfNotExists(key string, value interface{}, expiration time.Duration) (bool, error)
 	Get(key string) (string, error)
+	Increment(key string) (int64, error)
+	Expire(key string, expiration time.Duration) error
+	TTL(key string) (time.Duration, error)
 	Delete(key string) error
 }
 
@@ -20,6 +29,18 @@ func (r *redisServiceImpl) Set(key string, value interface{}) error {
 	return nil
 }
 
+func (r *redisServiceImpl) SetWithExpiration(key string, value interface{}, expiration time.Duration) error {
+	if err := r.repository.SetWithExpiration(key, value, expiration); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *redisServiceImpl) SetIfNotExists(key string, value interface{}, expiration time.Duration) (bool, error) {
+	return r.repository.SetIfNotExists(key, value, expiration)
+}
+
 func (r *redisServiceImpl) Get(key string) (string, error) {
 	res, err := r.repository.Get(key)
 	if err != nil {
@@ -29,6 +50,18 @@ func (r *redisServiceImpl) Get(key string) (string, error) {
 	return res, nil
 }
 
+func (r *redisServiceImpl) Increment(key string) (int64, error) {
+	return r.repository.Increment(key)
+}
+
+func (r *redisServiceImpl) Expire(key string, expiration time.Duration) error {
+	return r.repository.Expire(key, expiration)
+}
+
+func (r *redisServiceImpl) TTL(key string) (time.Duration, error) {
+	return r.repository.TTL(key)
+}
+
 func (r *redisServiceImpl) Delete(key string) error {
 	if err := r.repository.Delete(key); err != nil {
 		return err
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"math"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+const (
+	RuleTypeRoundUp    = "round_up"
+	RuleTypePercentage = "percentage"
+
+	CashflowIncome  = "income"
+	CashflowExpense = "expense"
+)
+
+type RuleService interface {
+	CreateRule(req *dtos.SavingRuleRequest) (*dtos.SavingRuleResponse, error)
+	GetRules(userUuid string) ([]*dtos.SavingRuleResponse, error)
+	UpdateRule(uuid string, req *dtos.SavingRuleRequest) (*dtos.SavingRuleResponse, error)
+	DeleteRule(userUuid string, uuid string) error
+	GetRuleExecutions(userUuid string, ruleUuid string) ([]*dtos.RuleExecutionResponse, error)
+	PostCashflow(req *dtos.CashflowRequest) (*dtos.CashflowResponse, error)
+}
+
+type ruleServiceImpl struct {
+	repo         repositories.RuleRepository
+	badgeService BadgeService
+	validator    *validator.CustomValidator
+}
+
+// CreateRule implements RuleService.
+func (r *ruleServiceImpl) CreateRule(req *dtos.SavingRuleRequest) (*dtos.SavingRuleResponse, error) {
+	if err := r.validateRule(req); err != nil {
+		return nil, err
+	}
+
+	return r.repo.CreateRule(req)
+}
+
+// GetRules implements RuleService.
+func (r *ruleServiceImpl) GetRules(userUuid string) ([]*dtos.SavingRuleResponse, error) {
+	return r.repo.GetRules(userUuid)
+}
+
+// UpdateRule implements RuleService.
+func (r *ruleServiceImpl) UpdateRule(uuid string, req *dtos.SavingRuleRequest) (*dtos.SavingRuleResponse, error) {
+	if err := r.validateRule(req); err != nil {
+		return nil, err
+	}
+
+	return r.repo.UpdateRule(uuid, req)
+}
+
+// DeleteRule implements RuleService.
+func (r *ruleServiceImpl) DeleteRule(userUuid string, uuid string) error {
+	return r.repo.DeleteRule(userUuid, uuid)
+}
+
+// GetRuleExecutions implements RuleService.
+func (r *ruleServiceImpl) GetRuleExecutions(userUuid string, ruleUuid string) ([]*dtos.RuleExecutionResponse, error) {
+	return r.repo.GetRuleExecutions(userUuid, ruleUuid)
+}
+
+// PostCashflow implements RuleService.
+func (r *ruleServiceImpl) PostCashflow(req *dtos.CashflowRequest) (*dtos.CashflowResponse, error) {
+	if err := r.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	response, err := r.repo.PostCashflow(req, evaluateRule)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Executions) > 0 {
+		_, _ = r.badgeService.Evaluate(req.UserUUID, BadgeEventTransactionPosted)
+	}
+
+	return response, nil
+}
+
+// validateRule checks the request and the parameter required by the rule type
+func (r *ruleServiceImpl) validateRule(req *dtos.SavingRuleRequest) error {
+	if err := r.validator.Validate(req); err != nil {
+		return err
+	}
+
+	switch req.Type {
+	case RuleTypeRoundUp:
+		if req.RoundTo == nil {
+			return &validator.ValidationError{Message: "round_to: this field is required"}
+		}
+		req.Percentage = nil
+	case RuleTypePercentage:
+		if req.Percentage == nil {
+			return &validator.ValidationError{Message: "percentage: this field is required"}
+		}
+		req.RoundTo = nil
+	}
+
+	return nil
+}
+
+// evaluateRule returns how much a rule saves for the given cashflow event.
+// Round-up rules react to expenses, percentage rules react to incomes.
+func evaluateRule(rule *models.SavingRule, event *models.CashflowEvent) float64 {
+	switch {
+	case rule.Type == RuleTypeRoundUp && event.Type == CashflowExpense && rule.RoundTo != nil:
+		amount := toCents(event.Amount)
+		step := toCents(*rule.RoundTo)
+		if step <= 0 || amount%step == 0 {
+			return 0
+		}
+		return fromCents(step - amount%step)
+	case rule.Type == RuleTypePercentage && event.Type == CashflowIncome && rule.Percentage != nil:
+		return fromCents(int64(math.Floor(float64(toCents(event.Amount)) * *rule.Percentage / 100)))
+	}
+
+	return 0
+}
+
+func NewRuleService(repo repositories.RuleRepository, badgeService BadgeService, validator *validator.CustomValidator) RuleService {
+	return &ruleServiceImpl{repo: repo, badgeService: badgeService, validator: validator}
+}
@@ -3,27 +3,62 @@ package services
 import (
 	"alfredo/tabunganku/pkg/dtos"
 	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
 )
 
 type SavingService interface {
 	CreateSaving(saving *dtos.SavingRequest) (response *dtos.SavingResponse, err error)
-	GetSavings(userUuid string) (response []*dtos.SavingResponse, err error)
+	GetSavings(userUuid string, filter *dtos.SavingFilter) (response []*dtos.SavingResponse, err error)
+	GetSummary(userUuid string) (response *dtos.SavingSummaryResponse, err error)
+	ReplaceSavingTags(userUuid string, savingUuid string, req *dtos.SavingTagsRequest) (response []string, err error)
 }
 
 type savingServiceImpl struct {
 	savingRepository repositories.SavingRepository
+	badgeService     BadgeService
+	validator        *validator.CustomValidator
 }
 
 // CreateSaving implements SavingService.
 func (s *savingServiceImpl) CreateSaving(saving *dtos.SavingRequest) (response *dtos.SavingResponse, err error) {
-	return s.savingRepository.CreateSaving(saving)
+	if err := s.validator.Validate(saving); err != nil {
+		return nil, err
+	}
+
+	response, err = s.savingRepository.CreateSaving(saving)
+	if err != nil {
+		return nil, err
+	}
+
+	// Badge tidak boleh menggagalkan pembuatan tabungan
+	_, _ = s.badgeService.Evaluate(saving.UserUUID, BadgeEventSavingCreated)
+
+	return response, nil
 }
 
 // GetSavings implements SavingService.
-func (s *savingServiceImpl) GetSavings(userUuid string) (response []*dtos.SavingResponse, err error) {
-	return s.savingRepository.GetSavings(userUuid)
+func (s *savingServiceImpl) GetSavings(userUuid string, filter *dtos.SavingFilter) (response []*dtos.SavingResponse, err error) {
+	if err := s.validator.Validate(filter); err != nil {
+		return nil, err
+	}
+
+	return s.savingRepository.GetSavings(userUuid, filter)
+}
+
+// GetSummary implements SavingService.
+func (s *savingServiceImpl) GetSummary(userUuid string) (response *dtos.SavingSummaryResponse, err error) {
+	return s.savingRepository.GetSummary(userUuid)
+}
+
+// ReplaceSavingTags implements SavingService.
+func (s *savingServiceImpl) ReplaceSavingTags(userUuid string, savingUuid string, req *dtos.SavingTagsRequest) (response []string, err error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	return s.savingRepository.ReplaceSavingTags(userUuid, savingUuid, req.Tags)
 }
 
-func NewSavingService(savingRepository repositories.SavingRepository) SavingService {
-	return &savingServiceImpl{savingRepository: savingRepository}
+func NewSavingService(savingRepository repositories.SavingRepository, badgeService BadgeService, validator *validator.CustomValidator) SavingService {
+	return &savingServiceImpl{savingRepository: savingRepository, badgeService: badgeService, validator: validator}
 }
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/repositories"
+	"alfredo/tabunganku/pkg/validator"
+)
+
+type SavingTransactionService interface {
+	CreateTransaction(req *dtos.SavingTransactionRequest) (*dtos.SavingTransactionResponse, error)
+	GetTransactions(userUuid string, savingUuid string) ([]*dtos.SavingTransactionResponse, error)
+}
+
+type savingTransactionServiceImpl struct {
+	repo         repositories.SavingTransactionRepository
+	badgeService BadgeService
+	validator    *validator.CustomValidator
+}
+
+// CreateTransaction implements SavingTransactionService.
+func (s *savingTransactionServiceImpl) CreateTransaction(req *dtos.SavingTransactionRequest) (*dtos.SavingTransactionResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	response, err := s.repo.CreateTransaction(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Badge tidak boleh menggagalkan transaksi yang sudah tercatat
+	_, _ = s.badgeService.Evaluate(req.UserUUID, BadgeEventTransactionPosted)
+
+	return response, nil
+}
+
+// GetTransactions implements SavingTransactionService.
+func (s *savingTransactionServiceImpl) GetTransactions(userUuid string, savingUuid string) ([]*dtos.SavingTransactionResponse, error) {
+	return s.repo.GetTransactions(userUuid, savingUuid)
+}
+
+func NewSavingTransactionService(repo repositories.SavingTransactionRepository, badgeService BadgeService, validator *validator.CustomValidator) SavingTransactionService {
+	return &savingTransactionServiceImpl{repo: repo, badgeService: badgeService, validator: validator}
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"strconv"
+	"time"
+
+	"alfredo/tabunganku/pkg/dtos"
+	"alfredo/tabunganku/pkg/models"
+	"alfredo/tabunganku/pkg/repositories"
+)
+
+const (
+	sessionLastSeenPrefix     = "session_seen:"
+	sessionLastSeenExpiration = time.Hour * 24 * 30
+)
+
+type SessionService interface {
+	GetSessions(userUuid string, currentTokens string) ([]*dtos.SessionResponse, error)
+	RevokeSession(userUuid string, uuid string) error
+}
+
+type sessionServiceImpl struct {
+	repo         repositories.SessionRepository
+	jwtService   JwtService
+	redisService RedisService
+}
+
+// GetSessions implements SessionService.
+func (s *sessionServiceImpl) GetSessions(userUuid string, currentTokens string) ([]*dtos.SessionResponse, error) {
+	sessions, err := s.repo.GetSessions(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dtos.SessionResponse, 0, len(sessions))
+	for i := range sessions {
+		// Sesi yang sudah dicabut lewat refresh token reuse tidak ditampilkan lagi
+		if s.jwtService.IsFamilyRevoked(sessions[i].Tokens) {
+			continue
+		}
+
+		item := toSessionResponse(&sessions[i])
+		item.Current = sessions[i].Tokens == currentTokens
+		if lastSeen, ok := LastSeen(s.redisService, sessions[i].Tokens); ok && lastSeen.After(item.LastSeenAt) {
+			item.LastSeenAt = lastSeen
+		}
+		response = append(response, item)
+	}
+
+	return response, nil
+}
+
+// RevokeSession implements SessionService.
+func (s *sessionServiceImpl) RevokeSession(userUuid string, uuid string) error {
+	session, err := s.repo.FindSession(userUuid, uuid)
+	if err != nil {
+		return err
+	}
+
+	if err := s.jwtService.RevokeFamily(session.Tokens); err != nil {
+		return err
+	}
+
+	return s.repo.RevokeSession(session.Tokens)
+}
+
+// TouchSession stores the last time a session was used in Redis, so authenticated requests do not write to the database
+func TouchSession(redisService RedisService, tokens string) {
+	if tokens == "" {
+		return
+	}
+
+	_ = redisService.SetWithExpiration(sessionLastSeenPrefix+tokens, time.Now().Unix(), sessionLastSeenExpiration)
+}
+
+// LastSeen returns the last time a session was used according to Redis
+func LastSeen(redisService RedisService, tokens string) (time.Time, bool) {
+	res, err := redisService.Get(sessionLastSeenPrefix + tokens)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	unix, err := strconv.ParseInt(res, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(unix, 0), true
+}
+
+func toSessionResponse(session *models.UserSession) *dtos.SessionResponse {
+	response := &dtos.SessionResponse{UUID: session.UUID}
+	if session.DeviceName != nil {
+		response.DeviceName = *session.DeviceName
+	}
+	if session.UserAgent != nil {
+		response.UserAgent = *session.UserAgent
+	}
+	if session.IPAddress != nil {
+		response.IPAddress = *session.IPAddress
+	}
+	if session.LastSeenAt != nil {
+		response.LastSeenAt = *session.LastSeenAt
+	}
+	if session.CreatedAt != nil {
+		response.CreatedAt = *session.CreatedAt
+	}
+
+	return response
+}
+
+func NewSessionService(repo repositories.SessionRepository, jwtService JwtService, redisService RedisService) SessionService {
+	return &sessionServiceImpl{repo: repo, jwtService: jwtService, redisService: redisService}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"alfredo/tabunganku/config"
+)
+
+const (
+	SmsDriverWhatsApp = "whatsapp"
+	SmsDriverLog      = "log"
+
+	defaultSmsLogPath = "./storage/sms.log"
+)
+
+// SmsSenderService delivers short text messages to a phone number. The implementation is chosen by sms.driver in the config.
+type SmsSenderService interface {
+	Send(phoneNumber string, message string) error
+}
+
+type whatsAppSender struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// Send implements SmsSenderService.
+func (w *whatsAppSender) Send(phoneNumber string, message string) error {
+	payload, err := json.Marshal(map[string]string{
+		"phone":   phoneNumber,
+		"message": message,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", w.token)
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("failed to send message: status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// logSender is the local stand-in, messages are appended to a file and written to the log instead of being sent
+type logSender struct {
+	path string
+}
+
+// Send implements SmsSenderService.
+func (l *logSender) Send(phoneNumber string, message string) error {
+	log.Printf("sms to=%s", phoneNumber)
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("failed to create sms directory: %w", err)
+	}
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open sms log: %w", err)
+	}
+	defer file.Close()
+
+	entry := fmt.Sprintf("%s to=%s %s\n", time.Now().Format(time.RFC3339), phoneNumber, message)
+	if _, err := file.WriteString(entry); err != nil {
+		return fmt.Errorf("failed to write sms log: %w", err)
+	}
+
+	return nil
+}
+
+func NewSmsSenderService() SmsSenderService {
+	if config.SmsDriver == SmsDriverWhatsApp {
+		return &whatsAppSender{
+			url:    config.WhatsAppUrl,
+			token:  config.WhatsAppToken,
+			client: &http.Client{Timeout: 10 * time.Second},
+		}
+	}
+
+	path := config.SmsLogPath
+	if path == "" {
+		path = defaultSmsLogPath
+	}
+
+	return &logSender{path: path}
+}
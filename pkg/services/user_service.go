@@ -1,12 +1,19 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-playground/validator"
+	"github.com/google/uuid"
 
 	"alfredo/tabunganku/pkg/dtos"
 	"alfredo/tabunganku/pkg/helpers"
+	"alfredo/tabunganku/pkg/helpers/token"
 	"alfredo/tabunganku/pkg/models"
 	"alfredo/tabunganku/pkg/repositories"
 )
@@ -14,12 +21,28 @@ import (
 type UserService interface {
 	FindUserByUuid(uuid string) (*models.User, error)
 	Login(request *dtos.LoginRequest) (response dtos.LoginResponse, err error)
+	LoginWithOidc(request *dtos.OidcCallbackRequest) (response dtos.LoginResponse, err error)
+	VerifyMfa(request *dtos.MfaVerifyRequest) (response dtos.LoginResponse, err error)
 	Register(req *dtos.RegisterRequest) error
+	RefreshToken(req *dtos.RefreshTokenRequest) (response dtos.GenerateTokenResponse, err error)
+	Logout(accessToken string) error
+	LogoutAll(userUuid string) error
+	UnlockAccount(token string) error
+	CreateWebviewToken(claims *TokenClaims, req *dtos.WebviewTokenRequest) (dtos.WebviewTokenResponse, error)
+	AuthenticateApiKey(key string) (*TokenClaims, error)
 }
 
 type userServiceImpl struct {
-	repo       repositories.UserRepository
-	jwtService JwtService
+	repo                     repositories.UserRepository
+	sessionRepository        repositories.SessionRepository
+	jwtService               JwtService
+	emailVerificationService EmailVerificationService
+	mfaService               MfaService
+	loginGuard               LoginGuardService
+	apiKeyService            ApiKeyService
+	oidcService              OidcService
+	identityRepository       repositories.IdentityRepository
+	passwordPolicy           PasswordPolicyService
 }
 
 // FindUserByUuid implements UserService.
@@ -29,34 +52,323 @@ func (u *userServiceImpl) FindUserByUuid(uuid string) (*models.User, error) {
 
 // Login implements UserService.
 func (u *userServiceImpl) Login(request *dtos.LoginRequest) (response dtos.LoginResponse, err error) {
-	// Find user by email
+	if err := u.loginGuard.Check(request.Email, request.IPAddress); err != nil {
+		return response, err
+	}
+
+	// Email yang tidak terdaftar dan password salah mendapat error yang sama
 	user, err := u.repo.FindUserByEmail(request.Email)
 	if err != nil {
-		return response, fmt.Errorf("user not found")
+		// Hash tetap dicek supaya waktu respon tidak membedakan email yang tidak terdaftar
+		_, _ = helpers.CheckPasswordHashWithArgon2(request.Password, dummyPasswordHash())
+		if err := u.loginGuard.RecordFailure(request.Email, request.IPAddress, nil); err != nil {
+			return response, err
+		}
+		return response, ErrInvalidCredentials
 	}
 
 	// Check if the password same with the hash password
 	if ok, err := helpers.CheckPasswordHashWithArgon2(request.Password, user.Password); !ok || err != nil {
-		return response, fmt.Errorf("invalid email or password")
+		if err := u.loginGuard.RecordFailure(request.Email, request.IPAddress, user); err != nil {
+			return response, err
+		}
+		return response, ErrInvalidCredentials
+	}
+
+	u.loginGuard.RecordSuccess(request.Email)
+
+	// Hash lama di-upgrade ke parameter terbaru selagi password asli tersedia
+	if helpers.PasswordNeedsRehash(user.Password) {
+		u.rehashPassword(user, request.Password)
+	}
+
+	return u.completeLogin(user, request.DeviceName, request.UserAgent, request.IPAddress)
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     string
+)
+
+// dummyPasswordHash returns a hash made with the current settings, used to check passwords of unknown emails.
+// It is created on first use so the configured peppers are already loaded.
+func dummyPasswordHash() string {
+	dummyHashOnce.Do(func() {
+		hash, err := helpers.HashPassword(uuid.NewString())
+		if err != nil {
+			log.Println("Error while creating dummy password hash", "error", err)
+			return
+		}
+		dummyHash = hash
+	})
+	return dummyHash
+}
+
+// rehashPassword stores the password with the current hash settings. A failure does not block the login,
+// the upgrade is retried on the next one.
+func (u *userServiceImpl) rehashPassword(user *models.User, password string) {
+	hashedPassword, err := helpers.HashPassword(password)
+	if err != nil {
+		log.Println("Error while rehashing password", "user", user.UUID, "error", err)
+		return
+	}
+	if err := u.repo.UpdatePassword(user.UUID, hashedPassword); err != nil {
+		log.Println("Error while rehashing password", "user", user.UUID, "error", err)
+		return
+	}
+	user.Password = hashedPassword
+}
+
+// CheckPasswordPeppers is called at startup. Every pepper id used by a stored hash must still be configured,
+// otherwise those users can not login anymore.
+func CheckPasswordPeppers(repo repositories.UserRepository) error {
+	if err := helpers.LoadPasswordPeppers(); err != nil {
+		return err
+	}
+
+	ids, err := repo.GetPasswordPepperIds()
+	if err != nil {
+		return err
+	}
+
+	return helpers.CheckPepperIds(ids)
+}
+
+// LoginWithOidc implements UserService.
+// The provider identity is matched first, then an account with the same email is linked when both the provider
+// and the account verified it, otherwise a new account is created.
+func (u *userServiceImpl) LoginWithOidc(request *dtos.OidcCallbackRequest) (response dtos.LoginResponse, err error) {
+	if request.Error != "" {
+		return response, fmt.Errorf("%w: %s %s", ErrOidcProvider, request.Error, request.ErrorDescription)
+	}
+
+	identity, err := u.oidcService.Exchange(request.Provider, request.Code, request.State)
+	if err != nil {
+		return response, err
+	}
+
+	user, err := u.identityRepository.FindIdentityUser(identity.Provider, identity.Subject)
+	if err != nil && !errors.Is(err, repositories.ErrIdentityNotFound) {
+		return response, err
+	}
+
+	if user == nil {
+		// Akun hanya boleh ditautkan lewat email yang sudah diverifikasi provider
+		if identity.Email == "" || !identity.EmailVerified {
+			return response, ErrOidcEmailNotVerified
+		}
+
+		link := &models.UserIdentity{
+			Provider: identity.Provider,
+			Subject:  identity.Subject,
+			Email:    &identity.Email,
+		}
+
+		user, err = u.repo.FindUserByEmail(identity.Email)
+		if err == nil {
+			// Siapa pun bisa register dengan email orang lain, jadi akun yang belum diverifikasi tidak ditautkan.
+			// Kalau ditautkan, pendaftar pertama tetap bisa masuk dengan password-nya.
+			if user.EmailVerifiedAt == nil {
+				return response, ErrOidcAccountUnverified
+			}
+			if err := u.identityRepository.LinkIdentity(user, link); err != nil {
+				return response, err
+			}
+		} else {
+			user, err = u.createOidcUser(identity, link)
+			if err != nil {
+				return response, err
+			}
+		}
+	}
+
+	return u.completeLogin(user, request.DeviceName, request.UserAgent, request.IPAddress)
+}
+
+// createOidcUser registers a new account for a provider identity. The password is random,
+// the user can set one later through forgot password.
+func (u *userServiceImpl) createOidcUser(identity *OidcIdentity, link *models.UserIdentity) (*models.User, error) {
+	password := token.URLSafe(token.SessionEntropy)
+	hashedPassword, err := helpers.HashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	name := identity.Name
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	now := time.Now()
+	user := &models.User{
+		Name:            truncate(name, 255),
+		Email:           identity.Email,
+		Password:        hashedPassword,
+		EmailVerifiedAt: &now,
+	}
+	if err := u.identityRepository.CreateUserWithIdentity(user, link); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// completeLogin asks for the second factor when TOTP is enabled, otherwise starts the session
+func (u *userServiceImpl) completeLogin(user *models.User, deviceName string, userAgent string, ipAddress string) (response dtos.LoginResponse, err error) {
+	if user.DisabledAt != nil {
+		return response, ErrAccountDisabled
+	}
+
+	// Token asli baru diberikan setelah kode 2FA diverifikasi di /auth/mfa/verify
+	if user.TotpEnabledAt != nil {
+		mfaToken, err := u.jwtService.GenerateMfaToken(user.UUID)
+		if err != nil {
+			return response, fmt.Errorf("failed to generate token")
+		}
+
+		return dtos.LoginResponse{
+			Email:         user.Email,
+			UserUuid:      user.UUID,
+			Name:          user.Name,
+			EmailVerified: user.EmailVerifiedAt != nil,
+			MfaRequired:   true,
+			MfaToken:      mfaToken,
+		}, nil
+	}
+
+	return u.startSession(user, deviceName, userAgent, ipAddress)
+}
+
+// VerifyMfa implements UserService.
+func (u *userServiceImpl) VerifyMfa(request *dtos.MfaVerifyRequest) (response dtos.LoginResponse, err error) {
+	if request.Code == "" {
+		return response, ErrMfaInvalidCode
+	}
+
+	userUuid, tokenId, err := u.jwtService.ParseMfaToken(request.MfaToken)
+	if err != nil {
+		return response, err
+	}
+
+	user, err := u.repo.FindUserByUuid(userUuid)
+	if err != nil {
+		return response, ErrInvalidToken
+	}
+
+	if err := u.mfaService.VerifyCode(user, request.Code); err != nil {
+		return response, err
 	}
 
-	generateToken := helpers.GenerateToken(32)
+	if err := u.jwtService.ConsumeMfaToken(tokenId); err != nil {
+		return response, err
+	}
+
+	return u.startSession(user, request.DeviceName, request.UserAgent, request.IPAddress)
+}
+
+// startSession issues the real token pair and records the login as a session
+func (u *userServiceImpl) startSession(user *models.User, deviceName string, userAgent string, ipAddress string) (response dtos.LoginResponse, err error) {
+	generateToken := token.URLSafe(token.SessionEntropy)
 	token, err := u.jwtService.GenerateToken(user.UUID, generateToken)
 	if err != nil {
 		return response, fmt.Errorf("failed to generate token")
 	}
 
+	// Setiap login menjadi satu sesi yang terikat ke claim tokens
+	session := models.UserSession{
+		UserUUID:   user.UUID,
+		Tokens:     generateToken,
+		DeviceName: optionalString(deviceName),
+		UserAgent:  optionalString(truncate(userAgent, 255)),
+		IPAddress:  optionalString(ipAddress),
+	}
+	if err := u.sessionRepository.CreateSession(&session); err != nil {
+		return response, fmt.Errorf("failed to create session")
+	}
+
 	return dtos.LoginResponse{
-		TokenType:    "Bearer",
-		ExpiresIn:    int64(token.ExpiresIn),
-		AccessToken:  token.AccessToken,
-		RefreshToken: token.RefreshToken,
-		Email:        user.Email,
-		UserUuid:     user.UUID,
-		Name:         user.Name,
+		TokenType:     "Bearer",
+		ExpiresIn:     int64(token.ExpiresIn),
+		AccessToken:   token.AccessToken,
+		RefreshToken:  token.RefreshToken,
+		Email:         user.Email,
+		UserUuid:      user.UUID,
+		Name:          user.Name,
+		SessionUuid:   session.UUID,
+		EmailVerified: user.EmailVerifiedAt != nil,
 	}, nil
 }
 
+// RefreshToken implements UserService.
+func (u *userServiceImpl) RefreshToken(req *dtos.RefreshTokenRequest) (response dtos.GenerateTokenResponse, err error) {
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return response, fmt.Errorf("invalid request")
+	}
+
+	response, err = u.jwtService.Refresh(req.RefreshToken)
+	if err != nil {
+		return response, err
+	}
+
+	// Refresh jarang terjadi, jadi last seen di database cukup diperbarui di sini
+	if claims, err := u.jwtService.ParseClaims(response.AccessToken); err == nil && claims.Tokens != "" {
+		_ = u.sessionRepository.TouchSession(claims.Tokens, time.Now())
+	}
+
+	return response, nil
+}
+
+// Logout implements UserService.
+func (u *userServiceImpl) Logout(accessToken string) error {
+	claims, err := u.jwtService.ParseClaims(accessToken)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	if err := u.jwtService.Revoke(accessToken); err != nil {
+		return err
+	}
+
+	// Refresh token dari login yang sama ikut dicabut lewat family-nya
+	if claims.Tokens != "" {
+		if err := u.jwtService.RevokeFamily(claims.Tokens); err != nil {
+			return err
+		}
+		return u.sessionRepository.RevokeSession(claims.Tokens)
+	}
+
+	return nil
+}
+
+// LogoutAll implements UserService.
+func (u *userServiceImpl) LogoutAll(userUuid string) error {
+	if err := u.jwtService.RevokeAll(userUuid); err != nil {
+		return err
+	}
+
+	return u.sessionRepository.RevokeAllSessions(userUuid)
+}
+
+// UnlockAccount implements UserService.
+func (u *userServiceImpl) UnlockAccount(token string) error {
+	return u.loginGuard.Unlock(token)
+}
+
+// CreateWebviewToken implements UserService.
+func (u *userServiceImpl) CreateWebviewToken(claims *TokenClaims, req *dtos.WebviewTokenRequest) (dtos.WebviewTokenResponse, error) {
+	if req.Path == "" {
+		return dtos.WebviewTokenResponse{}, ErrWebviewPathNotAllowed
+	}
+
+	return u.jwtService.GenerateWebviewToken(claims, req.Path)
+}
+
+// AuthenticateApiKey implements UserService.
+func (u *userServiceImpl) AuthenticateApiKey(key string) (*TokenClaims, error) {
+	return u.apiKeyService.Authenticate(key)
+}
+
 // Register implements UserService.
 func (u *userServiceImpl) Register(req *dtos.RegisterRequest) error {
 	validate := validator.New()
@@ -64,13 +376,61 @@ func (u *userServiceImpl) Register(req *dtos.RegisterRequest) error {
 		return fmt.Errorf("invalid request")
 	}
 
-	if err := u.repo.Register(req); err != nil {
+	if err := u.passwordPolicy.Check(req.Password, req.Name, req.Email); err != nil {
 		return err
 	}
 
+	user, err := u.repo.Register(req)
+	if err != nil {
+		return err
+	}
+
+	// Akun tetap dibuat walau email gagal dikirim, user bisa minta kirim ulang
+	if err := u.emailVerificationService.SendVerification(user); err != nil {
+		log.Println("Error while sending verification email", "error", err)
+	}
+
 	return nil
 }
 
-func NewUserService(repo repositories.UserRepository, jwtsService JwtService) UserService {
-	return &userServiceImpl{repo: repo, jwtService: jwtsService}
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+
+	return value[:length]
+}
+
+func NewUserService(
+	repo repositories.UserRepository,
+	sessionRepository repositories.SessionRepository,
+	jwtsService JwtService,
+	emailVerificationService EmailVerificationService,
+	mfaService MfaService,
+	loginGuard LoginGuardService,
+	apiKeyService ApiKeyService,
+	oidcService OidcService,
+	identityRepository repositories.IdentityRepository,
+	passwordPolicy PasswordPolicyService,
+) UserService {
+	return &userServiceImpl{
+		repo:                     repo,
+		sessionRepository:        sessionRepository,
+		jwtService:               jwtsService,
+		emailVerificationService: emailVerificationService,
+		mfaService:               mfaService,
+		loginGuard:               loginGuard,
+		apiKeyService:            apiKeyService,
+		oidcService:              oidcService,
+		identityRepository:       identityRepository,
+		passwordPolicy:           passwordPolicy,
+	}
 }
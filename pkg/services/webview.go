@@ -0,0 +1,44 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var ErrWebviewPathNotAllowed = errors.New("path can not be opened with a webview token")
+
+// webviewPaths are the read-only pages that accept a webview token from the ?token= query parameter
+var webviewPaths = []*regexp.Regexp{
+	regexp.MustCompile(`^/api/v1/savings/summary$`),
+	regexp.MustCompile(`^/api/v1/savings/[^/]+/timeline$`),
+	regexp.MustCompile(`^/api/v1/savings/[^/]+/transactions$`),
+	regexp.MustCompile(`^/api/v1/me/badges$`),
+}
+
+// webviewScopes limits webview tokens to reading, whatever the access token it was exchanged from can do
+var webviewScopes = []string{ScopeSavingsRead}
+
+// NormalizeWebviewPath strips the query string and trailing slash so the bound path compares exactly
+func NormalizeWebviewPath(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	if len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+
+	return path
+}
+
+// IsWebviewPathAllowed reports whether the page can be opened with a webview token
+func IsWebviewPathAllowed(path string) bool {
+	path = NormalizeWebviewPath(path)
+	for _, pattern := range webviewPaths {
+		if pattern.MatchString(path) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -13,6 +13,15 @@ type CustomValidator struct {
 	validator *validator.Validate
 }
 
+// ValidationError is returned by Validate when the struct fails validation
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
 // NewValidator creates a new validator instance with configured field name function
 func NewValidator() *CustomValidator {
 	validate := validator.New()
@@ -55,7 +64,7 @@ func (cv *CustomValidator) Validate(s interface{}) error {
 				errMsgs = append(errMsgs, errorMsg)
 			}
 
-			return errors.New(strings.Join(errMsgs, "; "))
+			return &ValidationError{Message: strings.Join(errMsgs, "; ")}
 		}
 	}
 	return nil